@@ -0,0 +1,292 @@
+package backend
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/umbracle/vesta/internal/server/proto"
+	"github.com/umbracle/vesta/internal/verify"
+)
+
+// artifactStore downloads the artifacts of the tasks into a content
+// addressed cache:
+//
+//	<dir>/
+//	├── sha256/<digest>   content of the artifacts
+//	├── sources/<hash>    digest of the last download of a source
+//	└── tmp/              downloads in progress
+type artifactStore struct {
+	dir string
+
+	// lock guards the source locks and the staged artifacts
+	lock sync.Mutex
+
+	// sources serialize the downloads of each source so that an artifact
+	// is not downloaded twice at the same time. Different sources are
+	// downloaded in parallel.
+	sources map[string]*sourceLock
+
+	// staged are the paths in the cache of the artifacts already
+	// fetched and verified
+	staged map[string]string
+
+	// download fetches the source into the dst file
+	download func(ctx context.Context, src, dst string) error
+}
+
+// sourceLock is the lock of a source with the number of fetches using it
+type sourceLock struct {
+	sync.Mutex
+	refs int
+}
+
+func newArtifactStore(dir string) *artifactStore {
+	return &artifactStore{
+		dir:      dir,
+		sources:  map[string]*sourceLock{},
+		staged:   map[string]string{},
+		download: getterDownload,
+	}
+}
+
+func getterDownload(ctx context.Context, src, dst string) error {
+	pwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	// copy local files instead of creating a symlink since
+	// the file is moved into the cache afterwards
+	getters := map[string]getter.Getter{}
+	for k, v := range getter.Getters {
+		getters[k] = v
+	}
+	getters["file"] = &getter.FileGetter{Copy: true}
+
+	client := &getter.Client{
+		Ctx:     ctx,
+		Src:     src,
+		Dst:     dst,
+		Pwd:     pwd,
+		Mode:    getter.ClientModeFile,
+		Getters: getters,
+	}
+	return client.Get()
+}
+
+// Fetch returns the path in the cache of the artifact. The artifact is
+// only downloaded if it is not in the cache yet. It returns a verify.Error
+// if the artifact does not match its sha256 digest or its signature.
+func (a *artifactStore) Fetch(ctx context.Context, artifact *proto.Task_Artifact) (string, error) {
+	if path, ok := a.Staged(artifact); ok {
+		return path, nil
+	}
+
+	unlock := a.lockSource(artifact.Source)
+	defer unlock()
+
+	// another fetch of the same source might have staged it meanwhile
+	if path, ok := a.Staged(artifact); ok {
+		return path, nil
+	}
+
+	path, err := a.fetchLocked(ctx, artifact)
+	if err != nil {
+		return "", err
+	}
+	if artifact.Signature != "" {
+		// the signature is verified even if the artifact is in the cache
+		// since the same content might have been fetched without signature
+		if err := a.verifySignature(ctx, artifact, path); err != nil {
+			return "", err
+		}
+	}
+
+	a.lock.Lock()
+	a.staged[artifactKey(artifact)] = path
+	a.lock.Unlock()
+
+	return path, nil
+}
+
+// Staged returns the path in the cache of the artifact if it was already
+// fetched and verified, without downloading it.
+func (a *artifactStore) Staged(artifact *proto.Task_Artifact) (string, bool) {
+	a.lock.Lock()
+	path, ok := a.staged[artifactKey(artifact)]
+	a.lock.Unlock()
+
+	if !ok {
+		return "", false
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+	return path, true
+}
+
+// lockSource takes the lock of the source and returns the function to release it
+func (a *artifactStore) lockSource(source string) func() {
+	a.lock.Lock()
+	l, ok := a.sources[source]
+	if !ok {
+		l = &sourceLock{}
+		a.sources[source] = l
+	}
+	l.refs++
+	a.lock.Unlock()
+
+	l.Lock()
+
+	return func() {
+		l.Unlock()
+
+		a.lock.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(a.sources, source)
+		}
+		a.lock.Unlock()
+	}
+}
+
+func (a *artifactStore) fetchLocked(ctx context.Context, artifact *proto.Task_Artifact) (string, error) {
+	var expected string
+	if artifact.Sha256 != "" {
+		digest, err := verify.ParseSHA256(artifact.Sha256)
+		if err != nil {
+			return "", err
+		}
+		expected = digest
+	} else {
+		// without sha256, the source points to the digest of the last download
+		data, err := os.ReadFile(a.sourcePath(artifact.Source))
+		if err != nil && !os.IsNotExist(err) {
+			return "", err
+		}
+		expected = string(data)
+	}
+
+	if expected != "" {
+		if _, err := os.Stat(a.blobPath(expected)); err == nil {
+			return a.blobPath(expected), nil
+		}
+	}
+
+	tmpDir, err := a.tempDir()
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tmpFile := filepath.Join(tmpDir, "artifact")
+	if err := a.download(ctx, artifact.Source, tmpFile); err != nil {
+		return "", fmt.Errorf("failed to download artifact '%s': %v", artifact.Source, err)
+	}
+	if artifact.Sha256 != "" {
+		if err := verify.SHA256(artifact.Source, tmpFile, expected); err != nil {
+			return "", err
+		}
+	}
+
+	digest, err := verify.FileDigest(tmpFile)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Join(a.dir, "sha256"), 0755); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmpFile, a.blobPath(digest)); err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Join(a.dir, "sources"), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(a.sourcePath(artifact.Source), []byte(digest), 0644); err != nil {
+		return "", err
+	}
+	return a.blobPath(digest), nil
+}
+
+func (a *artifactStore) verifySignature(ctx context.Context, artifact *proto.Task_Artifact, path string) error {
+	if artifact.PublicKey == "" {
+		return &verify.Error{Source: artifact.Source, Reason: "signature without public key"}
+	}
+
+	tmpDir, err := a.tempDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	sigFile := filepath.Join(tmpDir, "signature")
+	if err := a.download(ctx, artifact.Signature, sigFile); err != nil {
+		return fmt.Errorf("failed to download signature '%s': %v", artifact.Signature, err)
+	}
+	signature, err := os.ReadFile(sigFile)
+	if err != nil {
+		return err
+	}
+	return verify.Signature(artifact.Source, path, artifact.PublicKey, signature)
+}
+
+// artifactKey identifies an artifact with its verification
+func artifactKey(artifact *proto.Task_Artifact) string {
+	return strings.Join([]string{artifact.Source, artifact.Sha256, artifact.Signature, artifact.PublicKey}, "|")
+}
+
+func (a *artifactStore) tempDir() (string, error) {
+	if err := os.MkdirAll(filepath.Join(a.dir, "tmp"), 0755); err != nil {
+		return "", err
+	}
+	return os.MkdirTemp(filepath.Join(a.dir, "tmp"), "download-")
+}
+
+func (a *artifactStore) blobPath(digest string) string {
+	return filepath.Join(a.dir, "sha256", digest)
+}
+
+func (a *artifactStore) sourcePath(source string) string {
+	hash := sha256.Sum256([]byte(source))
+	return filepath.Join(a.dir, "sources", hex.EncodeToString(hash[:]))
+}
+
+// copyFile copies the file in src to dst atomically
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.CreateTemp(filepath.Dir(dst), ".artifact-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(out.Name())
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Chmod(0644); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return os.Rename(out.Name(), dst)
+}
@@ -0,0 +1,146 @@
+package backend
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/server/proto"
+	"github.com/umbracle/vesta/internal/verify"
+)
+
+func testArtifact(t *testing.T, content string) (string, string) {
+	path := filepath.Join(t.TempDir(), "genesis.ssz")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	hash := sha256.Sum256([]byte(content))
+	return path, hex.EncodeToString(hash[:])
+}
+
+func TestArtifactStore_Fetch(t *testing.T) {
+	src, digest := testArtifact(t, "genesis")
+
+	store := newArtifactStore(t.TempDir())
+
+	downloads := 0
+	store.download = func(ctx context.Context, src, dst string) error {
+		downloads++
+		return getterDownload(ctx, src, dst)
+	}
+
+	path, err := store.Fetch(context.Background(), &proto.Task_Artifact{Source: src})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "genesis", string(data))
+
+	// the artifact is served from the cache, with or without sha256
+	path2, err := store.Fetch(context.Background(), &proto.Task_Artifact{Source: src})
+	require.NoError(t, err)
+	require.Equal(t, path, path2)
+
+	path2, err = store.Fetch(context.Background(), &proto.Task_Artifact{Source: "other", Sha256: digest})
+	require.NoError(t, err)
+	require.Equal(t, path, path2)
+
+	require.Equal(t, 1, downloads)
+}
+
+func TestArtifactStore_Sha256(t *testing.T) {
+	src, _ := testArtifact(t, "genesis")
+	_, otherDigest := testArtifact(t, "other")
+
+	store := newArtifactStore(t.TempDir())
+
+	_, err := store.Fetch(context.Background(), &proto.Task_Artifact{Source: src, Sha256: otherDigest})
+	require.Error(t, err)
+	require.IsType(t, &verify.Error{}, err)
+}
+
+func TestArtifactStore_Signature(t *testing.T) {
+	src, _ := testArtifact(t, "genesis")
+
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	// minisign public key and signature of the artifact
+	keyID := pub[:8]
+	publicKey := base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), pub...))
+
+	sig := ed25519.Sign(priv, []byte("genesis"))
+	globalSig := ed25519.Sign(priv, append(append([]byte{}, sig...), "comment"...))
+	signature := "untrusted comment: signature\n" +
+		base64.StdEncoding.EncodeToString(append(append([]byte("Ed"), keyID...), sig...)) + "\n" +
+		"trusted comment: comment\n" +
+		base64.StdEncoding.EncodeToString(globalSig) + "\n"
+
+	sigPath := filepath.Join(t.TempDir(), "genesis.ssz.minisig")
+	require.NoError(t, os.WriteFile(sigPath, []byte(signature), 0644))
+
+	store := newArtifactStore(t.TempDir())
+
+	artifact := &proto.Task_Artifact{Source: src, Signature: sigPath, PublicKey: publicKey}
+	_, err = store.Fetch(context.Background(), artifact)
+	require.NoError(t, err)
+
+	// the signature does not match the content of the artifact
+	otherSrc, _ := testArtifact(t, "other")
+	artifact.Source = otherSrc
+	_, err = store.Fetch(context.Background(), artifact)
+	require.Error(t, err)
+	require.IsType(t, &verify.Error{}, err)
+
+	// signature without public key
+	_, err = store.Fetch(context.Background(), &proto.Task_Artifact{Source: src, Signature: sigPath})
+	require.Error(t, err)
+}
+
+func TestArtifactStore_Concurrent(t *testing.T) {
+	src, _ := testArtifact(t, "genesis")
+	otherSrc, _ := testArtifact(t, "other")
+
+	store := newArtifactStore(t.TempDir())
+
+	blockCh := make(chan struct{})
+	var lock sync.Mutex
+	downloads := map[string]int{}
+	store.download = func(ctx context.Context, s, dst string) error {
+		lock.Lock()
+		downloads[s]++
+		lock.Unlock()
+
+		if s == src {
+			<-blockCh
+		}
+		return getterDownload(ctx, s, dst)
+	}
+
+	errCh := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := store.Fetch(context.Background(), &proto.Task_Artifact{Source: src})
+			errCh <- err
+		}()
+	}
+
+	// the download of another source is not blocked by the first one
+	_, err := store.Fetch(context.Background(), &proto.Task_Artifact{Source: otherSrc})
+	require.NoError(t, err)
+
+	close(blockCh)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, <-errCh)
+	}
+
+	// the same source is only downloaded once
+	require.Equal(t, 1, downloads[src])
+	require.Equal(t, 1, downloads[otherSrc])
+}
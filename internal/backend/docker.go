@@ -0,0 +1,243 @@
+package backend
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/strslice"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// DockerDriver is a driver that runs the containers with Docker
+type DockerDriver struct {
+	client *client.Client
+}
+
+func NewDockerDriver() (*DockerDriver, error) {
+	client, err := client.NewClientWithOpts(client.FromEnv)
+	if err != nil {
+		return nil, err
+	}
+	client.NegotiateAPIVersion(context.Background())
+
+	d := &DockerDriver{
+		client: client,
+	}
+	return d, nil
+}
+
+func (d *DockerDriver) Create(ctx context.Context, spec *ContainerSpec) (string, error) {
+	config := &container.Config{
+		Image:  spec.Image,
+		Cmd:    strslice.StrSlice(spec.Cmd),
+		Labels: spec.Labels,
+	}
+	for k, v := range spec.Env {
+		config.Env = append(config.Env, k+"="+v)
+	}
+
+	hostConfig := &container.HostConfig{
+		Binds: spec.Binds,
+	}
+	if spec.NamespaceFrom != "" {
+		hostConfig.NetworkMode = container.NetworkMode("container:" + spec.NamespaceFrom)
+		hostConfig.PidMode = container.PidMode("container:" + spec.NamespaceFrom)
+	} else if spec.Network != "" {
+		hostConfig.NetworkMode = container.NetworkMode(spec.Network)
+	}
+
+	networkConfig := &network.NetworkingConfig{
+		EndpointsConfig: map[string]*network.EndpointSettings{},
+	}
+	if spec.Network != "" && spec.NamespaceFrom == "" {
+		networkConfig.EndpointsConfig[spec.Network] = &network.EndpointSettings{
+			Aliases: spec.Aliases,
+		}
+	}
+
+	body, err := d.client.ContainerCreate(ctx, config, hostConfig, networkConfig, nil, spec.Name)
+	if err != nil {
+		return "", err
+	}
+	return body.ID, nil
+}
+
+func (d *DockerDriver) Start(ctx context.Context, id string) error {
+	return d.client.ContainerStart(ctx, id, types.ContainerStartOptions{})
+}
+
+func (d *DockerDriver) Stop(ctx context.Context, id string, timeout time.Duration) error {
+	return d.client.ContainerStop(ctx, id, &timeout)
+}
+
+func (d *DockerDriver) Remove(ctx context.Context, id string, removeVolumes bool) error {
+	return d.client.ContainerRemove(ctx, id, types.ContainerRemoveOptions{RemoveVolumes: removeVolumes})
+}
+
+func (d *DockerDriver) Inspect(ctx context.Context, id string) (*Container, error) {
+	obj, err := d.client.ContainerInspect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Container{
+		ID:     obj.ID,
+		Name:   strings.TrimPrefix(obj.Name, "/"),
+		Image:  obj.Config.Image,
+		Labels: obj.Config.Labels,
+	}
+	if obj.State != nil {
+		c.State = ContainerState(obj.State.Status)
+		c.ExitCode = obj.State.ExitCode
+		c.Error = obj.State.Error
+		c.StartedAt, _ = time.Parse(time.RFC3339Nano, obj.State.StartedAt)
+		c.FinishedAt, _ = time.Parse(time.RFC3339Nano, obj.State.FinishedAt)
+	}
+	return c, nil
+}
+
+func (d *DockerDriver) List(ctx context.Context, labels map[string]string) ([]*Container, error) {
+	containers, err := d.client.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: labelFilters(labels)})
+	if err != nil {
+		return nil, err
+	}
+
+	res := []*Container{}
+	for _, obj := range containers {
+		c := &Container{
+			ID:     obj.ID,
+			Image:  obj.Image,
+			Labels: obj.Labels,
+			State:  ContainerState(obj.State),
+		}
+		if len(obj.Names) != 0 {
+			c.Name = strings.TrimPrefix(obj.Names[0], "/")
+		}
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+func (d *DockerDriver) Events(ctx context.Context, labels map[string]string) (<-chan *Event, <-chan error) {
+	filters := labelFilters(labels)
+	filters.Add("type", "container")
+
+	msgCh, errCh := d.client.Events(ctx, types.EventsOptions{Filters: filters})
+
+	eventCh := make(chan *Event)
+	go func() {
+		defer close(eventCh)
+
+		for {
+			select {
+			case msg := <-msgCh:
+				event := &Event{
+					ID:         msg.Actor.ID,
+					Action:     msg.Action,
+					Time:       time.Unix(0, msg.TimeNano),
+					Attributes: msg.Actor.Attributes,
+				}
+				select {
+				case eventCh <- event:
+				case <-ctx.Done():
+					return
+				}
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return eventCh, errCh
+}
+
+func labelFilters(labels map[string]string) filters.Args {
+	filters := filters.NewArgs()
+	for k, v := range labels {
+		filters.Add("label", k+"="+v)
+	}
+	return filters
+}
+
+func (d *DockerDriver) Logs(ctx context.Context, id string, opts *LogOptions, stdout, stderr io.Writer) error {
+	logOpts := types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     opts.Follow,
+		Tail:       opts.Tail,
+	}
+	if !opts.Since.IsZero() {
+		logOpts.Since = strconv.FormatInt(opts.Since.Unix(), 10)
+	}
+
+	out, err := d.client.ContainerLogs(ctx, id, logOpts)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	// the output of the containers without tty is multiplexed
+	if _, err := stdcopy.StdCopy(stdout, stderr, out); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (d *DockerDriver) Exec(ctx context.Context, id string, opts *ExecOptions) (int, error) {
+	config := types.ExecConfig{
+		Cmd:          opts.Cmd,
+		Tty:          opts.Tty,
+		AttachStdin:  opts.Stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+	}
+	execResp, err := d.client.ContainerExecCreate(ctx, id, config)
+	if err != nil {
+		return 0, err
+	}
+	execID := execResp.ID
+
+	resp, err := d.client.ContainerExecAttach(ctx, execID, types.ExecStartCheck{Tty: opts.Tty})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Close()
+
+	go handleExecIO(opts, resp.Conn, resp.CloseWrite, func(size TerminalSize) error {
+		return d.client.ContainerExecResize(ctx, execID, types.ResizeOptions{Height: size.Height, Width: size.Width})
+	})
+
+	if err := copyExecOutput(opts, resp.Reader); err != nil {
+		return 0, err
+	}
+
+	inspect, err := d.client.ContainerExecInspect(ctx, execID)
+	if err != nil {
+		return 0, err
+	}
+	return inspect.ExitCode, nil
+}
+
+func (d *DockerDriver) CreateNetwork(ctx context.Context, name string) error {
+	if _, err := d.client.NetworkInspect(ctx, name, types.NetworkInspectOptions{}); err == nil {
+		return nil
+	} else if !client.IsErrNotFound(err) {
+		return err
+	}
+
+	_, err := d.client.NetworkCreate(ctx, name, types.NetworkCreate{
+		CheckDuplicate: true,
+		Driver:         "bridge",
+		Labels:         map[string]string{"vesta": "true"},
+	})
+	return err
+}
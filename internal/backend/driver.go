@@ -0,0 +1,178 @@
+package backend
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// Driver is the interface implemented by the container runtimes
+// that run the tasks of the deployments.
+type Driver interface {
+	// Create creates a new container and returns its id
+	Create(ctx context.Context, spec *ContainerSpec) (string, error)
+
+	// Start starts a created container
+	Start(ctx context.Context, id string) error
+
+	// Stop stops a running container. The container is killed
+	// if it does not stop before the timeout
+	Stop(ctx context.Context, id string, timeout time.Duration) error
+
+	// Remove removes a stopped container. If removeVolumes is set,
+	// the volumes of the container are removed too.
+	Remove(ctx context.Context, id string, removeVolumes bool) error
+
+	// Inspect returns the current state of a container
+	Inspect(ctx context.Context, id string) (*Container, error)
+
+	// List returns all the containers (running or not) whose labels
+	// match all the given labels
+	List(ctx context.Context, labels map[string]string) ([]*Container, error)
+
+	// Events returns a stream with the lifecycle events of the
+	// containers whose labels match all the given labels
+	Events(ctx context.Context, labels map[string]string) (<-chan *Event, <-chan error)
+
+	// Logs writes the output of a container to stdout and stderr. If
+	// follow is set, it blocks until the container stops or the context
+	// is done.
+	Logs(ctx context.Context, id string, opts *LogOptions, stdout, stderr io.Writer) error
+
+	// Exec runs a command in a running container and returns its exit
+	// code once the command finishes.
+	Exec(ctx context.Context, id string, opts *ExecOptions) (int, error)
+
+	// CreateNetwork creates a bridge network if it does not exist
+	CreateNetwork(ctx context.Context, name string) error
+}
+
+// ExecOptions are the options to run a command in a container
+type ExecOptions struct {
+	Cmd []string
+
+	// Tty allocates a terminal for the command. The output of a terminal
+	// is not multiplexed, everything is written to Stdout.
+	Tty bool
+
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	// Resize receives the new sizes of the terminal
+	Resize <-chan TerminalSize
+}
+
+// TerminalSize is the size of the terminal of a command
+type TerminalSize struct {
+	Height uint
+	Width  uint
+}
+
+// LogOptions are the options to read the logs of a container
+type LogOptions struct {
+	// Follow keeps streaming the new output of the container
+	Follow bool
+
+	// Tail is the number of lines to return from the end of the
+	// logs. If empty, all the lines are returned.
+	Tail string
+
+	// Since only returns the logs after this time
+	Since time.Time
+}
+
+// ContainerSpec is the runtime agnostic specification of a container
+type ContainerSpec struct {
+	Name   string
+	Image  string
+	Cmd    []string
+	Env    map[string]string
+	Labels map[string]string
+
+	// Binds is the list of mounts in 'host:container' format
+	Binds []string
+
+	// Network is the name of the network the container joins
+	Network string
+
+	// Aliases are the DNS names of the container in the network
+	Aliases []string
+
+	// NamespaceFrom is the name of a container whose network
+	// and pid namespace are shared with this container.
+	NamespaceFrom string
+}
+
+type ContainerState string
+
+const (
+	ContainerStateCreated    ContainerState = "created"
+	ContainerStateRunning    ContainerState = "running"
+	ContainerStateRestarting ContainerState = "restarting"
+	ContainerStateExited     ContainerState = "exited"
+	ContainerStateDead       ContainerState = "dead"
+)
+
+// Container is the state of a container in the runtime
+type Container struct {
+	ID         string
+	Name       string
+	Image      string
+	Labels     map[string]string
+	State      ContainerState
+	ExitCode   int
+	Error      string
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Event is a lifecycle event of a container (i.e. create, start, die)
+type Event struct {
+	ID     string
+	Action string
+	Time   time.Time
+
+	// Attributes are the labels of the container plus any other
+	// runtime specific attribute (i.e. name, image, exitCode)
+	Attributes map[string]string
+}
+
+// handleExecIO forwards the input and the terminal resizes of an exec
+// session to the runtime.
+func handleExecIO(opts *ExecOptions, conn io.Writer, closeWrite func() error, resize func(TerminalSize) error) {
+	if opts.Resize != nil {
+		go func() {
+			for size := range opts.Resize {
+				resize(size)
+			}
+		}()
+	}
+	if opts.Stdin != nil {
+		io.Copy(conn, opts.Stdin)
+		closeWrite()
+	}
+}
+
+// copyExecOutput writes the output of an exec session. Without a terminal
+// the output uses the multiplexed format of Docker.
+func copyExecOutput(opts *ExecOptions, r io.Reader) error {
+	var err error
+	if opts.Tty {
+		_, err = io.Copy(opts.Stdout, r)
+	} else {
+		_, err = stdcopy.StdCopy(opts.Stdout, opts.Stderr, r)
+	}
+	return err
+}
+
+func matchLabels(labels, filter map[string]string) bool {
+	for k, v := range filter {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,374 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/umbracle/vesta/internal/uuid"
+)
+
+// MockDriver is an in-memory driver used for testing
+type MockDriver struct {
+	lock       sync.Mutex
+	containers map[string]*mockContainer
+	subs       map[*mockSubscriber]struct{}
+	networks   map[string]struct{}
+}
+
+type mockContainer struct {
+	container *Container
+	spec      *ContainerSpec
+	logs      []*mockLog
+	aliases   []string
+}
+
+type mockLog struct {
+	stderr bool
+	data   string
+}
+
+type mockSubscriber struct {
+	ctx    context.Context
+	labels map[string]string
+	ch     chan *Event
+}
+
+func NewMockDriver() *MockDriver {
+	return &MockDriver{
+		containers: map[string]*mockContainer{},
+		subs:       map[*mockSubscriber]struct{}{},
+		networks:   map[string]struct{}{},
+	}
+}
+
+func (m *MockDriver) Create(ctx context.Context, spec *ContainerSpec) (string, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if spec.Name != "" {
+		for _, c := range m.containers {
+			if c.container.Name == spec.Name {
+				return "", fmt.Errorf("container name '%s' already in use", spec.Name)
+			}
+		}
+	}
+	if spec.NamespaceFrom != "" {
+		if m.findByIDOrNameLocked(spec.NamespaceFrom) == nil {
+			return "", fmt.Errorf("container '%s' not found", spec.NamespaceFrom)
+		}
+	}
+
+	labels := map[string]string{}
+	for k, v := range spec.Labels {
+		labels[k] = v
+	}
+
+	c := &mockContainer{
+		container: &Container{
+			ID:     uuid.Generate(),
+			Name:   spec.Name,
+			Image:  spec.Image,
+			Labels: labels,
+			State:  ContainerStateCreated,
+		},
+		spec:    spec,
+		aliases: spec.Aliases,
+	}
+	m.containers[c.container.ID] = c
+	m.emitLocked(c.container, "create", nil)
+
+	return c.container.ID, nil
+}
+
+func (m *MockDriver) Start(ctx context.Context, id string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	c := m.findByIDOrNameLocked(id)
+	if c == nil {
+		return fmt.Errorf("container '%s' not found", id)
+	}
+	if c.container.State == ContainerStateRunning {
+		return nil
+	}
+	c.container.State = ContainerStateRunning
+	c.container.ExitCode = 0
+	c.container.StartedAt = time.Now()
+	m.emitLocked(c.container, "start", nil)
+
+	return nil
+}
+
+func (m *MockDriver) Stop(ctx context.Context, id string, timeout time.Duration) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	c := m.findByIDOrNameLocked(id)
+	if c == nil {
+		return fmt.Errorf("container '%s' not found", id)
+	}
+	if c.container.State != ContainerStateRunning {
+		return nil
+	}
+	m.exitLocked(c, 0)
+	m.emitLocked(c.container, "stop", nil)
+
+	return nil
+}
+
+func (m *MockDriver) Remove(ctx context.Context, id string, removeVolumes bool) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	c := m.findByIDOrNameLocked(id)
+	if c == nil {
+		return fmt.Errorf("container '%s' not found", id)
+	}
+	if c.container.State == ContainerStateRunning {
+		return fmt.Errorf("cannot remove running container '%s'", id)
+	}
+	delete(m.containers, c.container.ID)
+	m.emitLocked(c.container, "destroy", nil)
+
+	return nil
+}
+
+func (m *MockDriver) Inspect(ctx context.Context, id string) (*Container, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	c := m.findByIDOrNameLocked(id)
+	if c == nil {
+		return nil, fmt.Errorf("container '%s' not found", id)
+	}
+	return c.copy(), nil
+}
+
+func (m *MockDriver) List(ctx context.Context, labels map[string]string) ([]*Container, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	res := []*Container{}
+	for _, c := range m.containers {
+		if matchLabels(c.container.Labels, labels) {
+			res = append(res, c.copy())
+		}
+	}
+	return res, nil
+}
+
+func (m *MockDriver) Events(ctx context.Context, labels map[string]string) (<-chan *Event, <-chan error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	sub := &mockSubscriber{
+		ctx:    ctx,
+		labels: labels,
+		ch:     make(chan *Event, 100),
+	}
+	m.subs[sub] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+
+		m.lock.Lock()
+		delete(m.subs, sub)
+		m.lock.Unlock()
+	}()
+
+	return sub.ch, make(chan error)
+}
+
+func (m *MockDriver) Logs(ctx context.Context, id string, opts *LogOptions, stdout, stderr io.Writer) error {
+	m.lock.Lock()
+	c := m.findByIDOrNameLocked(id)
+	if c == nil {
+		m.lock.Unlock()
+		return fmt.Errorf("container '%s' not found", id)
+	}
+	logs := c.logs
+	m.lock.Unlock()
+
+	if opts.Tail != "" && opts.Tail != "all" {
+		num, err := strconv.Atoi(opts.Tail)
+		if err != nil {
+			return fmt.Errorf("incorrect tail value '%s'", opts.Tail)
+		}
+		if num < len(logs) {
+			logs = logs[len(logs)-num:]
+		}
+	}
+	for _, log := range logs {
+		w := stdout
+		if log.stderr {
+			w = stderr
+		}
+		if _, err := w.Write([]byte(log.data)); err != nil {
+			return err
+		}
+	}
+
+	if opts.Follow {
+		<-ctx.Done()
+	}
+	return nil
+}
+
+// Exec runs a command in the container. The mock writes the command
+// to stdout and then echoes the input.
+func (m *MockDriver) Exec(ctx context.Context, id string, opts *ExecOptions) (int, error) {
+	m.lock.Lock()
+	c := m.findByIDOrNameLocked(id)
+	if c == nil {
+		m.lock.Unlock()
+		return 0, fmt.Errorf("container '%s' not found", id)
+	}
+	if c.container.State != ContainerStateRunning {
+		m.lock.Unlock()
+		return 0, fmt.Errorf("container '%s' is not running", id)
+	}
+	m.lock.Unlock()
+
+	if _, err := fmt.Fprintln(opts.Stdout, strings.Join(opts.Cmd, " ")); err != nil {
+		return 0, err
+	}
+	if opts.Stdin != nil {
+		if _, err := io.Copy(opts.Stdout, opts.Stdin); err != nil {
+			return 0, err
+		}
+	}
+	return 0, nil
+}
+
+func (m *MockDriver) CreateNetwork(ctx context.Context, name string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.networks[name] = struct{}{}
+	return nil
+}
+
+// HasNetwork returns whether the network was created
+func (m *MockDriver) HasNetwork(name string) bool {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	_, ok := m.networks[name]
+	return ok
+}
+
+// Aliases returns the DNS aliases of the container in its network
+func (m *MockDriver) Aliases(id string) []string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	c := m.findByIDOrNameLocked(id)
+	if c == nil {
+		return nil
+	}
+	return c.aliases
+}
+
+// Log appends output to the logs of the container
+func (m *MockDriver) Log(id string, stderr bool, data string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	c := m.findByIDOrNameLocked(id)
+	if c == nil {
+		return fmt.Errorf("container '%s' not found", id)
+	}
+	c.logs = append(c.logs, &mockLog{stderr: stderr, data: data})
+	return nil
+}
+
+// Spec returns the specification used to create the container
+func (m *MockDriver) Spec(id string) *ContainerSpec {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	c := m.findByIDOrNameLocked(id)
+	if c == nil {
+		return nil
+	}
+	return c.spec
+}
+
+// Exit simulates the exit of a running container with the given exit code
+func (m *MockDriver) Exit(id string, exitCode int) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	c := m.findByIDOrNameLocked(id)
+	if c == nil {
+		return fmt.Errorf("container '%s' not found", id)
+	}
+	if c.container.State != ContainerStateRunning {
+		return fmt.Errorf("container '%s' is not running", id)
+	}
+	m.exitLocked(c, exitCode)
+
+	return nil
+}
+
+func (m *MockDriver) exitLocked(c *mockContainer, exitCode int) {
+	c.container.State = ContainerStateExited
+	c.container.ExitCode = exitCode
+	c.container.FinishedAt = time.Now()
+	m.emitLocked(c.container, "die", map[string]string{"exitCode": strconv.Itoa(exitCode)})
+}
+
+func (m *MockDriver) findByIDOrNameLocked(id string) *mockContainer {
+	if c, ok := m.containers[id]; ok {
+		return c
+	}
+	for _, c := range m.containers {
+		if c.container.Name == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func (m *MockDriver) emitLocked(c *Container, action string, extra map[string]string) {
+	attrs := map[string]string{
+		"name":  c.Name,
+		"image": c.Image,
+	}
+	for k, v := range c.Labels {
+		attrs[k] = v
+	}
+	for k, v := range extra {
+		attrs[k] = v
+	}
+
+	for sub := range m.subs {
+		if !matchLabels(c.Labels, sub.labels) {
+			continue
+		}
+		event := &Event{
+			ID:         c.ID,
+			Action:     action,
+			Time:       time.Now(),
+			Attributes: attrs,
+		}
+		select {
+		case sub.ch <- event:
+		case <-sub.ctx.Done():
+		}
+	}
+}
+
+func (c *mockContainer) copy() *Container {
+	obj := *c.container
+	obj.Labels = map[string]string{}
+	for k, v := range c.container.Labels {
+		obj.Labels[k] = v
+	}
+	return &obj
+}
@@ -0,0 +1,569 @@
+package backend
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// podmanAPIVersion is the version of the libpod REST API used
+var podmanAPIVersion = "v4.0.0"
+
+// PodmanDriver is a driver that runs the containers with Podman
+// using the libpod REST API exposed on the Podman socket.
+type PodmanDriver struct {
+	client *http.Client
+	dial   func(ctx context.Context) (net.Conn, error)
+}
+
+// DefaultPodmanSocket returns the path of the Podman socket. It uses
+// the rootless socket if the process is not run as root.
+func DefaultPodmanSocket() string {
+	if host := os.Getenv("CONTAINER_HOST"); strings.HasPrefix(host, "unix://") {
+		return strings.TrimPrefix(host, "unix://")
+	}
+	if os.Geteuid() != 0 {
+		if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+			return filepath.Join(dir, "podman", "podman.sock")
+		}
+	}
+	return "/run/podman/podman.sock"
+}
+
+func NewPodmanDriver(socket string) (*PodmanDriver, error) {
+	if socket == "" {
+		socket = DefaultPodmanSocket()
+	}
+	if _, err := os.Stat(socket); err != nil {
+		return nil, fmt.Errorf("podman socket not found: %v", err)
+	}
+
+	dial := func(ctx context.Context) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "unix", socket)
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dial(ctx)
+			},
+		},
+	}
+
+	d := &PodmanDriver{
+		client: client,
+		dial:   dial,
+	}
+	return d, nil
+}
+
+type podmanNamespace struct {
+	NSMode string `json:"nsmode"`
+	Value  string `json:"value,omitempty"`
+}
+
+type podmanMount struct {
+	Destination string   `json:"destination"`
+	Source      string   `json:"source"`
+	Type        string   `json:"type"`
+	Options     []string `json:"options,omitempty"`
+}
+
+type podmanSpec struct {
+	Name     string                 `json:"name,omitempty"`
+	Image    string                 `json:"image"`
+	Command  []string               `json:"command,omitempty"`
+	Env      map[string]string      `json:"env,omitempty"`
+	Labels   map[string]string      `json:"labels,omitempty"`
+	Mounts   []podmanMount          `json:"mounts,omitempty"`
+	NetNS    *podmanNamespace       `json:"netns,omitempty"`
+	PidNS    *podmanNamespace       `json:"pidns,omitempty"`
+	Networks map[string]interface{} `json:"Networks,omitempty"`
+}
+
+func (p *PodmanDriver) Create(ctx context.Context, spec *ContainerSpec) (string, error) {
+	req := &podmanSpec{
+		Name:    spec.Name,
+		Image:   spec.Image,
+		Command: spec.Cmd,
+		Env:     spec.Env,
+		Labels:  spec.Labels,
+	}
+	for _, bind := range spec.Binds {
+		parts := strings.SplitN(bind, ":", 2)
+		if len(parts) != 2 {
+			return "", fmt.Errorf("incorrect bind format '%s'", bind)
+		}
+		req.Mounts = append(req.Mounts, podmanMount{
+			Source:      parts[0],
+			Destination: parts[1],
+			Type:        "bind",
+			Options:     []string{"rbind"},
+		})
+	}
+	if spec.NamespaceFrom != "" {
+		// share the network and pid namespace with the init container
+		req.NetNS = &podmanNamespace{NSMode: "container", Value: spec.NamespaceFrom}
+		req.PidNS = &podmanNamespace{NSMode: "container", Value: spec.NamespaceFrom}
+	} else if spec.Network != "" {
+		req.NetNS = &podmanNamespace{NSMode: "bridge"}
+		req.Networks = map[string]interface{}{
+			spec.Network: map[string]interface{}{
+				"aliases": spec.Aliases,
+			},
+		}
+	}
+
+	var resp struct {
+		Id string
+	}
+	if err := p.do(ctx, http.MethodPost, "/containers/create", nil, req, &resp); err != nil {
+		return "", err
+	}
+	return resp.Id, nil
+}
+
+func (p *PodmanDriver) Start(ctx context.Context, id string) error {
+	return p.do(ctx, http.MethodPost, "/containers/"+id+"/start", nil, nil, nil)
+}
+
+func (p *PodmanDriver) Stop(ctx context.Context, id string, timeout time.Duration) error {
+	query := url.Values{}
+	query.Set("timeout", fmt.Sprintf("%d", int(timeout.Seconds())))
+
+	return p.do(ctx, http.MethodPost, "/containers/"+id+"/stop", query, nil, nil)
+}
+
+func (p *PodmanDriver) Remove(ctx context.Context, id string, removeVolumes bool) error {
+	query := url.Values{}
+	query.Set("v", fmt.Sprintf("%v", removeVolumes))
+
+	return p.do(ctx, http.MethodDelete, "/containers/"+id, query, nil, nil)
+}
+
+type podmanInspect struct {
+	Id     string
+	Name   string
+	Config struct {
+		Image  string
+		Labels map[string]string
+	}
+	State struct {
+		Status     string
+		ExitCode   int
+		Error      string
+		StartedAt  time.Time
+		FinishedAt time.Time
+	}
+}
+
+func (p *PodmanDriver) Inspect(ctx context.Context, id string) (*Container, error) {
+	var obj podmanInspect
+	if err := p.do(ctx, http.MethodGet, "/containers/"+id+"/json", nil, nil, &obj); err != nil {
+		return nil, err
+	}
+
+	c := &Container{
+		ID:         obj.Id,
+		Name:       obj.Name,
+		Image:      obj.Config.Image,
+		Labels:     obj.Config.Labels,
+		State:      podmanState(obj.State.Status),
+		ExitCode:   obj.State.ExitCode,
+		Error:      obj.State.Error,
+		StartedAt:  obj.State.StartedAt,
+		FinishedAt: obj.State.FinishedAt,
+	}
+	return c, nil
+}
+
+type podmanListContainer struct {
+	Id       string
+	Names    []string
+	Image    string
+	Labels   map[string]string
+	State    string
+	ExitCode int
+}
+
+func (p *PodmanDriver) List(ctx context.Context, labels map[string]string) ([]*Container, error) {
+	query := url.Values{}
+	query.Set("all", "true")
+	if err := setPodmanFilters(query, labels, nil); err != nil {
+		return nil, err
+	}
+
+	var objs []*podmanListContainer
+	if err := p.do(ctx, http.MethodGet, "/containers/json", query, nil, &objs); err != nil {
+		return nil, err
+	}
+
+	res := []*Container{}
+	for _, obj := range objs {
+		c := &Container{
+			ID:       obj.Id,
+			Image:    obj.Image,
+			Labels:   obj.Labels,
+			State:    podmanState(obj.State),
+			ExitCode: obj.ExitCode,
+		}
+		if len(obj.Names) != 0 {
+			c.Name = obj.Names[0]
+		}
+		res = append(res, c)
+	}
+	return res, nil
+}
+
+type podmanEvent struct {
+	Type     string
+	Action   string
+	TimeNano int64 `json:"timeNano"`
+	Actor    struct {
+		ID         string
+		Attributes map[string]string
+	}
+}
+
+// podmanActions maps the names of the Podman container events to the
+// ones used by Docker. Any other event is not tracked.
+var podmanActions = map[string]string{
+	"create":  "create",
+	"start":   "start",
+	"restart": "restart",
+	"kill":    "kill",
+	"stop":    "stop",
+	"died":    "die",
+	"remove":  "destroy",
+	"pause":   "pause",
+	"unpause": "unpause",
+}
+
+func (p *PodmanDriver) Events(ctx context.Context, labels map[string]string) (<-chan *Event, <-chan error) {
+	eventCh := make(chan *Event)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(eventCh)
+
+		if err := p.streamEvents(ctx, labels, eventCh); err != nil && ctx.Err() == nil {
+			errCh <- err
+		}
+	}()
+
+	return eventCh, errCh
+}
+
+func (p *PodmanDriver) streamEvents(ctx context.Context, labels map[string]string, eventCh chan<- *Event) error {
+	query := url.Values{}
+	query.Set("stream", "true")
+	if err := setPodmanFilters(query, labels, map[string][]string{"type": {"container"}}); err != nil {
+		return err
+	}
+
+	resp, err := p.request(ctx, http.MethodGet, "/events", query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	dec := json.NewDecoder(bufio.NewReader(resp.Body))
+	for {
+		var msg podmanEvent
+		if err := dec.Decode(&msg); err != nil {
+			return err
+		}
+		action, ok := podmanActions[msg.Action]
+		if !ok {
+			continue
+		}
+
+		attrs := map[string]string{}
+		for k, v := range msg.Actor.Attributes {
+			attrs[k] = v
+		}
+		if code, ok := attrs["containerExitCode"]; ok {
+			attrs["exitCode"] = code
+		}
+
+		event := &Event{
+			ID:         msg.Actor.ID,
+			Action:     action,
+			Time:       time.Unix(0, msg.TimeNano),
+			Attributes: attrs,
+		}
+		select {
+		case eventCh <- event:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (p *PodmanDriver) Logs(ctx context.Context, id string, opts *LogOptions, stdout, stderr io.Writer) error {
+	query := url.Values{}
+	query.Set("stdout", "true")
+	query.Set("stderr", "true")
+	query.Set("follow", fmt.Sprintf("%v", opts.Follow))
+	if opts.Tail != "" {
+		query.Set("tail", opts.Tail)
+	}
+	if !opts.Since.IsZero() {
+		query.Set("since", fmt.Sprintf("%d", opts.Since.Unix()))
+	}
+
+	resp, err := p.request(ctx, http.MethodGet, "/containers/"+id+"/logs", query, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// libpod uses the same multiplexed format as Docker
+	if _, err := stdcopy.StdCopy(stdout, stderr, resp.Body); err != nil {
+		return err
+	}
+	return nil
+}
+
+type podmanExecConfig struct {
+	AttachStdin  bool     `json:"AttachStdin"`
+	AttachStdout bool     `json:"AttachStdout"`
+	AttachStderr bool     `json:"AttachStderr"`
+	Cmd          []string `json:"Cmd"`
+	Tty          bool     `json:"Tty"`
+}
+
+func (p *PodmanDriver) Exec(ctx context.Context, id string, opts *ExecOptions) (int, error) {
+	config := &podmanExecConfig{
+		AttachStdin:  opts.Stdin != nil,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          opts.Cmd,
+		Tty:          opts.Tty,
+	}
+	var execResp struct {
+		Id string
+	}
+	if err := p.do(ctx, http.MethodPost, "/containers/"+id+"/exec", nil, config, &execResp); err != nil {
+		return 0, err
+	}
+	execID := execResp.Id
+
+	start := map[string]interface{}{
+		"Detach": false,
+		"Tty":    opts.Tty,
+	}
+	conn, reader, err := p.hijack(ctx, "/exec/"+execID+"/start", start)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	closeWrite := func() error {
+		if c, ok := conn.(interface{ CloseWrite() error }); ok {
+			return c.CloseWrite()
+		}
+		return nil
+	}
+	go handleExecIO(opts, conn, closeWrite, func(size TerminalSize) error {
+		query := url.Values{}
+		query.Set("h", fmt.Sprintf("%d", size.Height))
+		query.Set("w", fmt.Sprintf("%d", size.Width))
+		return p.do(ctx, http.MethodPost, "/exec/"+execID+"/resize", query, nil, nil)
+	})
+
+	if err := copyExecOutput(opts, reader); err != nil {
+		return 0, err
+	}
+
+	var inspect struct {
+		ExitCode int
+	}
+	if err := p.do(ctx, http.MethodGet, "/exec/"+execID+"/json", nil, nil, &inspect); err != nil {
+		return 0, err
+	}
+	return inspect.ExitCode, nil
+}
+
+func (p *PodmanDriver) CreateNetwork(ctx context.Context, name string) error {
+	query := url.Values{}
+	if err := setPodmanFilters(query, nil, map[string][]string{"name": {name}}); err != nil {
+		return err
+	}
+
+	var networks []struct {
+		Name string `json:"name"`
+	}
+	if err := p.do(ctx, http.MethodGet, "/networks/json", query, nil, &networks); err != nil {
+		return err
+	}
+	for _, network := range networks {
+		// the name filter also matches the networks that contain the name
+		if network.Name == name {
+			return nil
+		}
+	}
+
+	req := map[string]interface{}{
+		"name":   name,
+		"driver": "bridge",
+		"labels": map[string]string{"vesta": "true"},
+	}
+	return p.do(ctx, http.MethodPost, "/networks/create", nil, req, nil)
+}
+
+// hijack sends a request that upgrades the connection to a raw stream
+// (i.e. exec sessions) and returns the connection.
+func (p *PodmanDriver) hijack(ctx context.Context, path string, in interface{}) (net.Conn, *bufio.Reader, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "http://d/"+podmanAPIVersion+"/libpod"+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "tcp")
+
+	conn, err := p.dial(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer conn.Close()
+
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil || apiErr.Message == "" {
+			return nil, nil, fmt.Errorf("podman request POST %s failed with status %d", path, resp.StatusCode)
+		}
+		return nil, nil, fmt.Errorf("podman: %s", apiErr.Message)
+	}
+
+	// close the connection if the context is done before the stream ends
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	return conn, br, nil
+}
+
+func (p *PodmanDriver) do(ctx context.Context, method, path string, query url.Values, in, out interface{}) error {
+	resp, err := p.request(ctx, method, path, query, in)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		_, err := io.Copy(ioutil.Discard, resp.Body)
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode podman response: %v", err)
+	}
+	return nil
+}
+
+func (p *PodmanDriver) request(ctx context.Context, method, path string, query url.Values, in interface{}) (*http.Response, error) {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	u := "http://d/" + podmanAPIVersion + "/libpod" + path
+	if len(query) != 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil || apiErr.Message == "" {
+			return nil, fmt.Errorf("podman request %s %s failed with status %d", method, path, resp.StatusCode)
+		}
+		return nil, fmt.Errorf("podman: %s", apiErr.Message)
+	}
+	return resp, nil
+}
+
+func setPodmanFilters(query url.Values, labels map[string]string, extra map[string][]string) error {
+	filters := map[string][]string{}
+	for k, v := range extra {
+		filters[k] = v
+	}
+	for k, v := range labels {
+		filters["label"] = append(filters["label"], k+"="+v)
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(filters)
+	if err != nil {
+		return err
+	}
+	query.Set("filters", string(data))
+	return nil
+}
+
+func podmanState(status string) ContainerState {
+	switch status {
+	case "configured", "created", "initialized":
+		return ContainerStateCreated
+	case "running", "paused", "stopping":
+		return ContainerStateRunning
+	case "stopped", "exited":
+		return ContainerStateExited
+	default:
+		return ContainerState(status)
+	}
+}
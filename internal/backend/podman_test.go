@@ -0,0 +1,149 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/stretchr/testify/require"
+)
+
+func testPodmanDriver(t *testing.T, handler http.Handler) *PodmanDriver {
+	socket := filepath.Join(t.TempDir(), "podman.sock")
+
+	lis, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	srv := &http.Server{Handler: handler}
+	go srv.Serve(lis)
+
+	t.Cleanup(func() {
+		srv.Close()
+	})
+
+	d, err := NewPodmanDriver(socket)
+	require.NoError(t, err)
+	return d
+}
+
+func TestPodman_Create(t *testing.T) {
+	var spec podmanSpec
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v4.0.0/libpod/containers/create", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&spec))
+
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"Id": "abcd", "Warnings": []}`)
+	})
+	d := testPodmanDriver(t, mux)
+
+	id, err := d.Create(context.Background(), &ContainerSpec{
+		Name:          "task",
+		Image:         "vesta:latest",
+		Cmd:           []string{"a"},
+		Labels:        map[string]string{"vesta": "true"},
+		Binds:         []string{"/tmp/a:/data"},
+		NamespaceFrom: "init-a",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "abcd", id)
+
+	require.Equal(t, "task", spec.Name)
+	require.Equal(t, "vesta:latest", spec.Image)
+	require.Equal(t, []string{"a"}, spec.Command)
+
+	// the task shares the namespaces of the init container
+	require.Equal(t, &podmanNamespace{NSMode: "container", Value: "init-a"}, spec.NetNS)
+	require.Equal(t, &podmanNamespace{NSMode: "container", Value: "init-a"}, spec.PidNS)
+
+	require.Len(t, spec.Mounts, 1)
+	require.Equal(t, "/tmp/a", spec.Mounts[0].Source)
+	require.Equal(t, "/data", spec.Mounts[0].Destination)
+}
+
+func TestPodman_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v4.0.0/libpod/containers/a/start", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"cause": "no such container", "message": "no container with name or ID \"a\" found", "response": 404}`)
+	})
+	d := testPodmanDriver(t, mux)
+
+	err := d.Start(context.Background(), "a")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no container with name or ID")
+}
+
+func TestPodman_Events(t *testing.T) {
+	events := []string{
+		`{"Type": "container", "Action": "create", "Actor": {"ID": "a", "Attributes": {"name": "task", "deployment": "b"}}}`,
+		`{"Type": "container", "Action": "init", "Actor": {"ID": "a", "Attributes": {"name": "task", "deployment": "b"}}}`,
+		`{"Type": "container", "Action": "died", "timeNano": 1683022365000000000, "Actor": {"ID": "a", "Attributes": {"name": "task", "deployment": "b", "containerExitCode": "1"}}}`,
+		`{"Type": "container", "Action": "remove", "Actor": {"ID": "a", "Attributes": {"name": "task", "deployment": "b"}}}`,
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v4.0.0/libpod/events", func(w http.ResponseWriter, r *http.Request) {
+		var filters map[string][]string
+		require.NoError(t, json.Unmarshal([]byte(r.URL.Query().Get("filters")), &filters))
+		require.Equal(t, []string{"vesta=true"}, filters["label"])
+
+		for _, event := range events {
+			fmt.Fprintln(w, event)
+		}
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	d := testPodmanDriver(t, mux)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventCh, _ := d.Events(ctx, map[string]string{"vesta": "true"})
+
+	var received []*Event
+	for len(received) != 3 {
+		select {
+		case event := <-eventCh:
+			received = append(received, event)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	}
+
+	// the 'init' event is not tracked and the events use
+	// the same names as in Docker
+	require.Equal(t, "create", received[0].Action)
+	require.Equal(t, "die", received[1].Action)
+	require.Equal(t, "1", received[1].Attributes["exitCode"])
+	require.Equal(t, int64(1683022365), received[1].Time.Unix())
+	require.Equal(t, "destroy", received[2].Action)
+	require.Equal(t, "b", received[2].Attributes["deployment"])
+}
+
+func TestPodman_Logs(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v4.0.0/libpod/containers/a/logs", func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "true", r.URL.Query().Get("stdout"))
+		require.Equal(t, "10", r.URL.Query().Get("tail"))
+
+		stdcopy.NewStdWriter(w, stdcopy.Stdout).Write([]byte("a\n"))
+		stdcopy.NewStdWriter(w, stdcopy.Stderr).Write([]byte("b\n"))
+	})
+	d := testPodmanDriver(t, mux)
+
+	var stdout, stderr bytes.Buffer
+	require.NoError(t, d.Logs(context.Background(), "a", &LogOptions{Tail: "10"}, &stdout, &stderr))
+
+	require.Equal(t, "a\n", stdout.String())
+	require.Equal(t, "b\n", stderr.String())
+}
@@ -3,93 +3,278 @@ package backend
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
-	"github.com/docker/docker/api/types"
-	"github.com/docker/docker/api/types/container"
-	"github.com/docker/docker/api/types/filters"
-	"github.com/docker/docker/api/types/network"
-	"github.com/docker/docker/api/types/strslice"
-	"github.com/docker/docker/client"
+	"github.com/hashicorp/go-hclog"
 	"github.com/umbracle/vesta/internal/server/proto"
 	"github.com/umbracle/vesta/internal/uuid"
 )
 
 type Swarm struct {
-	client  *client.Client
+	logger  hclog.Logger
+	driver  Driver
 	updater Updater
+
+	config    *SwarmConfig
+	artifacts *artifactStore
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	doneCh chan struct{}
+}
+
+// SwarmConfig are the host directories used by the containers of the tasks
+type SwarmConfig struct {
+	// DataDir is the directory where the data files of the tasks
+	// are rendered before they are mounted
+	DataDir string
+
+	// VolumesDir is the directory with the volumes of the tasks. The
+	// volumes are kept until the deployment is destroyed with purge.
+	VolumesDir string
+
+	// ArtifactsDir is the cache of the artifacts downloaded for the tasks
+	ArtifactsDir string
+
+	// Network is the network shared by the deployments. It
+	// defaults to DefaultNetwork if empty.
+	Network string
 }
 
+// DefaultNetwork is the default network of the deployments
+const DefaultNetwork = "vesta"
+
 type Updater interface {
 	UpdateEvent(event *proto.Event2)
 }
 
-func NewSwarm(updater Updater) *Swarm {
-	client, err := client.NewClientWithOpts(client.FromEnv)
-	if err != nil {
-		panic(err)
-	}
-	client.NegotiateAPIVersion(context.Background())
+func NewSwarm(logger hclog.Logger, driver Driver, updater Updater, config *SwarmConfig) *Swarm {
+	ctx, cancel := context.WithCancel(context.Background())
 
 	s := &Swarm{
-		client:  client,
-		updater: updater,
+		logger:    logger.Named("swarm"),
+		driver:    driver,
+		updater:   updater,
+		config:    config,
+		artifacts: newArtifactStore(config.ArtifactsDir),
+		ctx:       ctx,
+		cancel:    cancel,
+		doneCh:    make(chan struct{}),
 	}
-	go s.trackEventUpdates()
+
+	// subscribe before returning so that no event is lost
+	eventCh, errCh := s.driver.Events(s.ctx, map[string]string{"vesta": "true"})
+	go s.trackEventUpdates(eventCh, errCh)
 
 	return s
 }
 
-func (s *Swarm) trackEventUpdates() {
-	filters := filters.NewArgs()
-	filters.Add("label", "vesta=true")
+// Stop stops tracking the events of the containers and waits
+// until no more events are sent to the updater
+func (s *Swarm) Stop() {
+	s.cancel()
+	<-s.doneCh
+}
 
-	msgCh, errCh := s.client.Events(context.Background(), types.EventsOptions{Filters: filters})
+func (s *Swarm) trackEventUpdates(eventCh <-chan *Event, errCh <-chan error) {
+	defer close(s.doneCh)
 
 	for {
 		select {
-		case msg := <-msgCh:
-			if msg.Actor.Attributes["role"] == "init-container" {
+		case msg, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			if msg.Attributes["role"] == "init-container" {
 				// we do not want to return attributes from this container
 				continue
 			}
 
-			deployment, ok := msg.Actor.Attributes["deployment"]
+			deployment, ok := msg.Attributes["deployment"]
 			if !ok {
-				panic("??")
+				s.logger.Warn("event without deployment label", "id", msg.ID, "action", msg.Action)
+				continue
+			}
+			// the deployment and the task are already part of the event
+			attrs := map[string]string{}
+			for k, v := range msg.Attributes {
+				if k != "deployment" && k != "task" {
+					attrs[k] = v
+				}
 			}
 			event := &proto.Event2{
 				Id:         uuid.Generate(),
 				Deployment: deployment,
-				Task:       msg.Actor.Attributes["name"],
+				Task:       msg.Attributes["task"],
 				Type:       msg.Action,
+				Timestamp:  msg.Time.UnixNano(),
+				Attributes: attrs,
 			}
 			s.updater.UpdateEvent(event)
 
 		case err := <-errCh:
-			fmt.Println("err", err)
+			s.logger.Error("failed to track events", "err", err)
+
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// Tasks returns the containers of the tasks of a deployment
+// indexed by the name of the task.
+func (s *Swarm) Tasks(deployment string) (map[string]*Container, error) {
+	containers, err := s.driver.List(s.ctx, map[string]string{"deployment": deployment})
+	if err != nil {
+		return nil, err
+	}
+
+	res := map[string]*Container{}
+	for _, c := range containers {
+		if c.Labels["role"] == "init-container" {
+			continue
 		}
+		res[c.Labels["task"]] = c
 	}
+	return res, nil
 }
 
-func (s *Swarm) Deploy(name string, tasks map[string]*proto.Task) error {
+// RunTask creates and starts the container of a task of the deployment
+func (s *Swarm) RunTask(deployment, name string, task *proto.Task) error {
 	// create the network reference
-	initRes := s.createNetworkContainer(name)
+	initRes, err := s.ensureNetworkContainer(deployment, "")
+	if err != nil {
+		return err
+	}
 
-	for name, t := range tasks {
-		opts, err := s.createContainerOptions(t, initRes)
-		if err != nil {
+	spec, err := s.createContainerSpec(deployment, name, task, initRes.Name)
+	if err != nil {
+		return err
+	}
+	id, err := s.driver.Create(s.ctx, spec)
+	if err != nil {
+		return fmt.Errorf("failed to create task '%s': %v", name, err)
+	}
+	if err := s.driver.Start(s.ctx, id); err != nil {
+		return fmt.Errorf("failed to start task '%s': %v", name, err)
+	}
+	return nil
+}
+
+// StartTask starts again the stopped container of a task
+func (s *Swarm) StartTask(deployment, id string) error {
+	if _, err := s.ensureNetworkContainer(deployment, ""); err != nil {
+		return err
+	}
+	return s.driver.Start(s.ctx, id)
+}
+
+// InspectTask returns the current state of the container of a task
+func (s *Swarm) InspectTask(id string) (*Container, error) {
+	return s.driver.Inspect(s.ctx, id)
+}
+
+// FetchArtifacts downloads and verifies the artifacts of the task. The
+// artifacts are fetched by RunTask otherwise, this allows to fetch them
+// beforehand without holding any lock of the caller.
+func (s *Swarm) FetchArtifacts(ctx context.Context, task *proto.Task) error {
+	for _, artifact := range task.Artifacts {
+		if _, err := s.artifacts.Fetch(ctx, artifact); err != nil {
 			return err
 		}
-		body, err := s.client.ContainerCreate(context.Background(), opts.config, opts.host, opts.network, nil, name)
-		if err != nil {
+	}
+	return nil
+}
+
+// ArtifactsStaged returns whether all the artifacts of the task are
+// fetched and verified, so that RunTask does not download them
+func (s *Swarm) ArtifactsStaged(task *proto.Task) bool {
+	for _, artifact := range task.Artifacts {
+		if _, ok := s.artifacts.Staged(artifact); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// RemoveTask stops and removes the container of a task
+func (s *Swarm) RemoveTask(id string) error {
+	return s.removeContainer(id, false)
+}
+
+// Logs writes the output of the container of a task
+func (s *Swarm) Logs(ctx context.Context, id string, opts *LogOptions, stdout, stderr io.Writer) error {
+	return s.driver.Logs(ctx, id, opts, stdout, stderr)
+}
+
+// Exec runs a command in the container of a task
+func (s *Swarm) Exec(ctx context.Context, id string, opts *ExecOptions) (int, error) {
+	return s.driver.Exec(ctx, id, opts)
+}
+
+var (
+	// stopTimeout is the time to wait for a container to stop
+	// gracefully before it is killed
+	stopTimeout = 30 * time.Second
+)
+
+// Destroy stops and removes all the containers (including the network
+// container) that belong to the deployment. If purgeVolumes is set, the
+// volumes of the tasks are removed too.
+func (s *Swarm) Destroy(deployment string, purgeVolumes bool) error {
+	containers, err := s.driver.List(s.ctx, map[string]string{"deployment": deployment})
+	if err != nil {
+		return err
+	}
+
+	// the tasks share the namespace of the network container, remove
+	// it only after all the tasks are gone.
+	var initContainers []*Container
+	for _, c := range containers {
+		if c.Labels["role"] == "init-container" {
+			initContainers = append(initContainers, c)
+			continue
+		}
+		if err := s.removeContainer(c.ID, purgeVolumes); err != nil {
 			return err
 		}
-		if err := s.client.ContainerStart(context.Background(), body.ID, types.ContainerStartOptions{}); err != nil {
+	}
+	for _, c := range initContainers {
+		if err := s.removeContainer(c.ID, purgeVolumes); err != nil {
 			return err
 		}
 	}
 
+	// remove the data files rendered for the tasks
+	if err := os.RemoveAll(filepath.Join(s.config.DataDir, deployment)); err != nil {
+		return fmt.Errorf("failed to remove data files: %v", err)
+	}
+	if purgeVolumes {
+		if err := os.RemoveAll(filepath.Join(s.config.VolumesDir, deployment)); err != nil {
+			return fmt.Errorf("failed to remove volumes: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// VolumePath returns the directory in the host of a volume of a task
+func (s *Swarm) VolumePath(deployment, task, volume string) string {
+	return filepath.Join(s.config.VolumesDir, deployment, task, volume)
+}
+
+func (s *Swarm) removeContainer(id string, purgeVolumes bool) error {
+	if err := s.driver.Stop(s.ctx, id, stopTimeout); err != nil {
+		return fmt.Errorf("failed to stop container %s: %v", id, err)
+	}
+	if err := s.driver.Remove(s.ctx, id, purgeVolumes); err != nil {
+		return fmt.Errorf("failed to remove container %s: %v", id, err)
+	}
 	return nil
 }
 
@@ -97,90 +282,205 @@ var (
 	networkInfraImage = "gcr.io/google_containers/pause-amd64:3.1"
 )
 
-type createContainerOptions struct {
-	name    string
-	config  *container.Config
-	host    *container.HostConfig
-	network *network.NetworkingConfig
-}
-
-func (s *Swarm) createNetworkContainer(name string) string {
-	initContainerName := "init-" + name
-
-	opts := &createContainerOptions{
-		name: initContainerName,
-		config: &container.Config{
-			Image:    networkInfraImage,
-			Hostname: "",
-			Labels: map[string]string{
-				"vesta": "true",
-				"role":  "init-container",
-			},
-		},
-		host: &container.HostConfig{
-			NetworkMode: container.NetworkMode("vesta"),
-		},
-		network: &network.NetworkingConfig{
-			EndpointsConfig: map[string]*network.EndpointSettings{},
-		},
+// CreateNetwork creates the network shared by the deployments
+// if it does not exist
+func (s *Swarm) CreateNetwork() error {
+	if err := s.driver.CreateNetwork(s.ctx, s.network()); err != nil {
+		return fmt.Errorf("failed to create network '%s': %v", s.network(), err)
 	}
+	return nil
+}
 
-	body, err := s.client.ContainerCreate(context.Background(), opts.config, opts.host, opts.network, nil, opts.name)
+// EnsureNetwork makes sure that the network container of the deployment
+// is running and that the deployment is reachable in the network by its
+// id and its alias.
+func (s *Swarm) EnsureNetwork(deployment, alias string) error {
+	c, err := s.ensureNetworkContainer(deployment, alias)
 	if err != nil {
-		panic(err)
+		return err
 	}
-	if err := s.client.ContainerStart(context.Background(), body.ID, types.ContainerStartOptions{}); err != nil {
-		panic(err)
+	if c.Labels["alias"] == alias {
+		return nil
 	}
 
-	_, err = s.client.ContainerInspect(context.Background(), body.ID)
+	// the labels of a container cannot be updated, the network container is
+	// created again with the new alias. The containers of the tasks share its
+	// namespace and they are removed too, the reconciler creates them again.
+	s.logger.Info("update network aliases", "deployment", deployment, "alias", alias)
+
+	containers, err := s.driver.List(s.ctx, map[string]string{"deployment": deployment})
 	if err != nil {
-		panic(err)
+		return err
+	}
+	for _, c := range containers {
+		if err := s.removeContainer(c.ID, false); err != nil {
+			return err
+		}
+	}
+	if _, err := s.createNetworkContainer(deployment, alias); err != nil {
+		return err
 	}
+	return nil
+}
 
-	return initContainerName
+// ensureNetworkContainer makes sure that the network container of
+// the deployment exists and it is running. If the container is
+// created, it is reachable in the network by the alias.
+func (s *Swarm) ensureNetworkContainer(deployment, alias string) (*Container, error) {
+	containers, err := s.driver.List(s.ctx, map[string]string{"deployment": deployment, "role": "init-container"})
+	if err != nil {
+		return nil, err
+	}
+	if len(containers) == 0 {
+		return s.createNetworkContainer(deployment, alias)
+	}
+
+	c := containers[0]
+	if c.State != ContainerStateRunning {
+		if err := s.driver.Start(s.ctx, c.ID); err != nil {
+			return nil, fmt.Errorf("failed to start network container: %v", err)
+		}
+	}
+	return c, nil
 }
 
-func (s *Swarm) createContainerOptions(task *proto.Task, network string) (*createContainerOptions, error) {
+func (s *Swarm) createNetworkContainer(deployment, alias string) (*Container, error) {
+	spec := &ContainerSpec{
+		Name:  "init-" + deployment,
+		Image: networkInfraImage,
+		Labels: map[string]string{
+			"vesta":      "true",
+			"role":       "init-container",
+			"deployment": deployment,
+			"alias":      alias,
+		},
+		Network: s.network(),
+		Aliases: networkAliases(deployment, alias),
+	}
+
+	id, err := s.driver.Create(s.ctx, spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create network container: %v", err)
+	}
+	if err := s.driver.Start(s.ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to start network container: %v", err)
+	}
+
+	c := &Container{
+		ID:     id,
+		Name:   spec.Name,
+		Image:  spec.Image,
+		Labels: spec.Labels,
+		State:  ContainerStateRunning,
+	}
+	return c, nil
+}
+
+func (s *Swarm) network() string {
+	if s.config.Network == "" {
+		return DefaultNetwork
+	}
+	return s.config.Network
+}
+
+// networkAliases returns the DNS names of a deployment in the network
+func networkAliases(deployment, alias string) []string {
+	aliases := []string{deployment}
+	if alias != "" {
+		aliases = append(aliases, alias)
+	}
+	return aliases
+}
+
+func (s *Swarm) createContainerSpec(deployment, name string, task *proto.Task, network string) (*ContainerSpec, error) {
 	labels := map[string]string{}
 	for k, v := range task.Labels {
 		labels[k] = v
 	}
 	// append system wide labels
 	labels["vesta"] = "true"
+	labels["deployment"] = deployment
+	labels["task"] = name
 
-	config := &container.Config{
-		Image:  task.Image + ":" + task.Tag,
-		Cmd:    strslice.StrSlice(task.Args),
-		Labels: labels,
+	spec := &ContainerSpec{
+		Name:          name + "-" + deployment,
+		Image:         task.Image + ":" + task.Tag,
+		Cmd:           task.Args,
+		Env:           task.Env,
+		Labels:        labels,
+		Binds:         []string{},
+		NamespaceFrom: network,
 	}
-	for k, v := range task.Env {
-		config.Env = append(config.Env, k+"="+v)
+
+	// the data files may contain secrets (i.e. the JWT secret), only the
+	// owner can read the directory of the deployment. The containers
+	// read the files through their bind mounts.
+	deploymentDir := filepath.Join(s.config.DataDir, deployment)
+	if err := os.MkdirAll(deploymentDir, 0700); err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(deploymentDir, 0700); err != nil {
+		return nil, err
+	}
+
+	// the data files mirror their path inside the container
+	// under the directory of the task
+	taskDir := filepath.Join(deploymentDir, name)
+	for path, data := range task.Data {
+		hostPath := filepath.Join(taskDir, filepath.Clean("/"+path))
+		if err := os.MkdirAll(filepath.Dir(hostPath), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(hostPath, []byte(data), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write data file '%s': %v", path, err)
+		}
+		spec.Binds = append(spec.Binds, hostPath+":"+path)
 	}
 
-	hostConfig := &container.HostConfig{
-		Binds:       []string{},
-		NetworkMode: container.NetworkMode("container:" + network),
-		PidMode:     container.PidMode("container:" + network),
+	// the volumes are directories that outlive the containers
+	// so that the data is kept when the task is replaced
+	for volName, vol := range task.Volumes {
+		hostPath := s.VolumePath(deployment, name, volName)
+		if err := os.MkdirAll(hostPath, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create volume '%s': %v", volName, err)
+		}
+		spec.Binds = append(spec.Binds, hostPath+":"+vol.Path)
 	}
 
-	for folder, data := range task.Data {
-		f, err := os.CreateTemp("", "vesta")
+	// the artifacts are placed inside the volume that contains their
+	// destination or they are mounted as files otherwise
+	for _, artifact := range task.Artifacts {
+		src, err := s.artifacts.Fetch(s.ctx, artifact)
 		if err != nil {
 			return nil, err
 		}
-		if _, err := f.Write([]byte(data)); err != nil {
-			return nil, err
+
+		var hostPath string
+		for volName, vol := range task.Volumes {
+			if rel, ok := volumeRelPath(vol.Path, artifact.Destination); ok {
+				hostPath = filepath.Join(s.VolumePath(deployment, name, volName), rel)
+				break
+			}
 		}
-		if err := f.Close(); err != nil {
-			return nil, err
+		if hostPath == "" {
+			hostPath = filepath.Join(taskDir, filepath.Clean("/"+artifact.Destination))
+			spec.Binds = append(spec.Binds, hostPath+":"+artifact.Destination)
+		}
+		if err := copyFile(src, hostPath); err != nil {
+			return nil, fmt.Errorf("failed to place artifact '%s': %v", artifact.Source, err)
 		}
-		hostConfig.Binds = append(hostConfig.Binds, f.Name()+":"+folder)
 	}
+	sort.Strings(spec.Binds)
+
+	return spec, nil
+}
 
-	opts := &createContainerOptions{
-		config: config,
-		host:   hostConfig,
+// volumeRelPath returns the path of the file relative to the volume
+// if the file is inside the volume
+func volumeRelPath(volume, file string) (string, bool) {
+	rel, err := filepath.Rel(volume, file)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", false
 	}
-	return opts, nil
+	return rel, true
 }
@@ -0,0 +1,229 @@
+package backend
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/mock"
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+type mockUpdater struct {
+	lock   sync.Mutex
+	events []*proto.Event2
+}
+
+func (m *mockUpdater) UpdateEvent(event *proto.Event2) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.events = append(m.events, event)
+}
+
+func (m *mockUpdater) Events() []*proto.Event2 {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return append([]*proto.Event2{}, m.events...)
+}
+
+func TestSwarm_TrackEvents(t *testing.T) {
+	updater := &mockUpdater{}
+
+	s := NewSwarm(hclog.NewNullLogger(), NewMockDriver(), updater, testSwarmConfig(t))
+	defer s.Stop()
+
+	require.NoError(t, s.RunTask("a", "task", mock.Task()))
+
+	// only the events of the task are tracked, not the ones
+	// from the network container
+	require.Eventually(t, func() bool {
+		return len(updater.Events()) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	for _, event := range updater.Events() {
+		require.Equal(t, "a", event.Deployment)
+		require.Equal(t, "task", event.Task)
+		require.NotZero(t, event.Timestamp)
+
+		// the attributes do not repeat the deployment and the task
+		require.NotEmpty(t, event.Attributes["image"])
+		require.Empty(t, event.Attributes["deployment"])
+	}
+	require.Equal(t, "create", updater.Events()[0].Type)
+	require.Equal(t, "start", updater.Events()[1].Type)
+}
+
+func testSwarmConfig(t *testing.T) *SwarmConfig {
+	return &SwarmConfig{
+		DataDir:      t.TempDir(),
+		VolumesDir:   t.TempDir(),
+		ArtifactsDir: t.TempDir(),
+	}
+}
+
+func TestSwarm_Network(t *testing.T) {
+	driver := NewMockDriver()
+
+	s := NewSwarm(hclog.NewNullLogger(), driver, &mockUpdater{}, testSwarmConfig(t))
+	defer s.Stop()
+
+	require.NoError(t, s.CreateNetwork())
+	require.True(t, driver.HasNetwork(DefaultNetwork))
+
+	// the network container is named after the deployment
+	require.NoError(t, s.EnsureNetwork("a", "node"))
+
+	spec := driver.Spec("init-a")
+	require.NotNil(t, spec)
+	require.Equal(t, DefaultNetwork, spec.Network)
+	require.Equal(t, []string{"a", "node"}, driver.Aliases("init-a"))
+
+	// the tasks share the namespace of the network container
+	require.NoError(t, s.RunTask("a", "task", mock.Task()))
+	require.Equal(t, "init-a", driver.Spec("task-a").NamespaceFrom)
+
+	// the network container is created again when the alias changes,
+	// along with the tasks that share its namespace
+	require.NoError(t, s.EnsureNetwork("a", "node2"))
+	require.Equal(t, []string{"a", "node2"}, driver.Aliases("init-a"))
+	require.Nil(t, driver.Spec("task-a"))
+
+	containers, err := driver.List(context.Background(), map[string]string{"deployment": "a", "role": "init-container"})
+	require.NoError(t, err)
+	require.Len(t, containers, 1)
+	require.Equal(t, "node2", containers[0].Labels["alias"])
+
+	// it is not created again if the alias does not change (i.e. after a restart)
+	s2 := NewSwarm(hclog.NewNullLogger(), driver, &mockUpdater{}, testSwarmConfig(t))
+	defer s2.Stop()
+
+	require.NoError(t, s2.EnsureNetwork("a", "node2"))
+
+	current, err := driver.List(context.Background(), map[string]string{"deployment": "a", "role": "init-container"})
+	require.NoError(t, err)
+	require.Len(t, current, 1)
+	require.Equal(t, containers[0].ID, current[0].ID)
+
+	require.NoError(t, s.EnsureNetwork("a", ""))
+	require.Equal(t, []string{"a"}, driver.Aliases("init-a"))
+
+	// each deployment has its own network container
+	require.NoError(t, s.EnsureNetwork("b", ""))
+	require.Equal(t, []string{"b"}, driver.Aliases("init-b"))
+}
+
+func TestSwarm_DataFiles(t *testing.T) {
+	config := testSwarmConfig(t)
+
+	driver := NewMockDriver()
+	s := NewSwarm(hclog.NewNullLogger(), driver, &mockUpdater{}, config)
+	defer s.Stop()
+
+	task := mock.Task()
+	task.Data = map[string]string{
+		"/data/config.toml": "a",
+	}
+	require.NoError(t, s.RunTask("a", "task", task))
+
+	// the data file is rendered under the directory of the task
+	hostPath := filepath.Join(config.DataDir, "a", "task", "data", "config.toml")
+	data, err := os.ReadFile(hostPath)
+	require.NoError(t, err)
+	require.Equal(t, "a", string(data))
+
+	// only the owner can read the directory of the deployment
+	info, err := os.Stat(filepath.Join(config.DataDir, "a"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	spec := driver.Spec("task-a")
+	require.Equal(t, []string{hostPath + ":/data/config.toml"}, spec.Binds)
+
+	// the data files are removed with the deployment
+	require.NoError(t, s.Destroy("a", false))
+
+	_, err = os.Stat(filepath.Join(config.DataDir, "a"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestSwarm_Volumes(t *testing.T) {
+	config := testSwarmConfig(t)
+
+	driver := NewMockDriver()
+	s := NewSwarm(hclog.NewNullLogger(), driver, &mockUpdater{}, config)
+	defer s.Stop()
+
+	task := mock.Task()
+	task.Volumes = map[string]*proto.Task_Volume{
+		"data": {Path: "/data"},
+	}
+	require.NoError(t, s.RunTask("a", "task", task))
+
+	hostPath := filepath.Join(config.VolumesDir, "a", "task", "data")
+	require.Equal(t, []string{hostPath + ":/data"}, driver.Spec("task-a").Binds)
+
+	// write some data in the volume
+	require.NoError(t, os.WriteFile(filepath.Join(hostPath, "chain"), []byte("a"), 0644))
+
+	// the volume is kept when the task is replaced
+	containers, err := s.Tasks("a")
+	require.NoError(t, err)
+	require.NoError(t, s.RemoveTask(containers["task"].ID))
+	require.NoError(t, s.RunTask("a", "task", task))
+
+	_, err = os.Stat(filepath.Join(hostPath, "chain"))
+	require.NoError(t, err)
+
+	// and when the deployment is destroyed without purge
+	require.NoError(t, s.Destroy("a", false))
+
+	_, err = os.Stat(filepath.Join(hostPath, "chain"))
+	require.NoError(t, err)
+
+	require.NoError(t, s.RunTask("a", "task", task))
+	require.NoError(t, s.Destroy("a", true))
+
+	_, err = os.Stat(filepath.Join(config.VolumesDir, "a"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestSwarm_Artifacts(t *testing.T) {
+	config := testSwarmConfig(t)
+
+	src := filepath.Join(t.TempDir(), "genesis.ssz")
+	require.NoError(t, os.WriteFile(src, []byte("genesis"), 0644))
+
+	driver := NewMockDriver()
+	s := NewSwarm(hclog.NewNullLogger(), driver, &mockUpdater{}, config)
+	defer s.Stop()
+
+	task := mock.Task()
+	task.Volumes = map[string]*proto.Task_Volume{
+		"data": {Path: "/data"},
+	}
+	task.Artifacts = []*proto.Task_Artifact{
+		{Source: src, Destination: "/data/genesis.ssz"},
+		{Source: src, Destination: "/config/genesis.ssz"},
+	}
+	require.NoError(t, s.RunTask("a", "task", task))
+
+	// the artifact is placed inside the volume
+	data, err := os.ReadFile(filepath.Join(config.VolumesDir, "a", "task", "data", "genesis.ssz"))
+	require.NoError(t, err)
+	require.Equal(t, "genesis", string(data))
+
+	// or mounted as a file if there is no volume
+	hostPath := filepath.Join(config.DataDir, "a", "task", "config", "genesis.ssz")
+	data, err = os.ReadFile(hostPath)
+	require.NoError(t, err)
+	require.Equal(t, "genesis", string(data))
+
+	require.Contains(t, driver.Spec("task-a").Binds, hostPath+":/config/genesis.ssz")
+}
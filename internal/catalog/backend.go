@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/docker/go-units"
 	"github.com/mitchellh/mapstructure"
 	"github.com/umbracle/vesta/internal/framework"
 	"github.com/umbracle/vesta/internal/server/proto"
@@ -14,8 +15,13 @@ type backend struct {
 	thread  *starlark.Thread
 	globals starlark.StringDict
 	name    string
+	version string
+	kind    string
 	fields  map[string]*framework.Field
 	chains  []string
+
+	// expected disk size for each chain
+	diskSize map[string]uint64
 }
 
 func newBackend(content []byte) framework.Framework {
@@ -44,6 +50,7 @@ type field struct {
 	ForceNew      bool          `mapstructure:"force_new"`
 	Description   string        `mapstructure:"description"`
 	AllowedValues []interface{} `mapstructure:"allowed_values"`
+	Kind          string        `mapstructure:"kind"`
 }
 
 func (f *field) ToType() *framework.Field {
@@ -53,6 +60,7 @@ func (f *field) ToType() *framework.Field {
 		ForceNew:      f.ForceNew,
 		Description:   f.Description,
 		AllowedValues: f.AllowedValues,
+		Kind:          f.Kind,
 	}
 	if f.Type == "string" {
 		res.Type = framework.TypeString
@@ -60,6 +68,8 @@ func (f *field) ToType() *framework.Field {
 		res.Type = framework.TypeBool
 	} else if f.Type == "int" {
 		res.Type = framework.TypeInt
+	} else if f.Type == "reference" {
+		res.Type = framework.TypeReference
 	} else {
 		panic(fmt.Sprintf("type '%s' not found", f.Type))
 	}
@@ -72,6 +82,20 @@ func (b *backend) generateStaticConfig() error {
 		return err
 	}
 
+	if versionValue, ok := b.globals["version"]; ok {
+		if err := mapstructure.Decode(toGoValue(versionValue), &b.version); err != nil {
+			return err
+		}
+	}
+
+	// the kind is optional and it is only required if the
+	// plugin is referenced by the fields of other plugins
+	if kindValue, ok := b.globals["kind"]; ok {
+		if err := mapstructure.Decode(toGoValue(kindValue), &b.kind); err != nil {
+			return err
+		}
+	}
+
 	configValue := b.globals["config"]
 
 	var configResult map[string]*field
@@ -93,6 +117,23 @@ func (b *backend) generateStaticConfig() error {
 	if err := mapstructure.Decode(toGoValue(chainsValue), &b.chains); err != nil {
 		return err
 	}
+
+	// the disk size is optional and it is declared in a human
+	// readable format (i.e. 1TB) for each chain
+	b.diskSize = map[string]uint64{}
+	if diskSizeValue, ok := b.globals["disk_size"]; ok {
+		var diskSize map[string]string
+		if err := mapstructure.Decode(toGoValue(diskSizeValue), &diskSize); err != nil {
+			return err
+		}
+		for chain, size := range diskSize {
+			num, err := units.FromHumanSize(size)
+			if err != nil {
+				return fmt.Errorf("invalid disk size for chain '%s': %v", chain, err)
+			}
+			b.diskSize[chain] = uint64(num)
+		}
+	}
 	return nil
 }
 
@@ -113,10 +154,23 @@ func (b *backend) Chains() []string {
 	return b.chains
 }
 
+func (b *backend) Kind() string {
+	return b.kind
+}
+
+func (b *backend) DiskSize() map[string]uint64 {
+	return b.diskSize
+}
+
+func (b *backend) Version() string {
+	return b.version
+}
+
 func (b *backend) Generate(config *framework.Config) map[string]*proto.Task {
 	input := starlark.NewDict(1)
 	input.SetKey(starlark.String("chain"), starlark.String(config.Chain))
 	input.SetKey(starlark.String("metrics"), starlark.Bool(config.Metrics))
+	input.SetKey(starlark.String("jwt_secret"), starlark.String(config.JWTSecret))
 
 	for name := range config.Data.Schema {
 		val := config.Data.Get(name)
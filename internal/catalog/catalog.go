@@ -1,13 +1,16 @@
 package catalog
 
 import (
+	"crypto/rand"
 	"embed"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io/fs"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/go-hclog"
@@ -22,8 +25,25 @@ var builtinBackends embed.FS
 type Catalog struct {
 	logger   hclog.Logger
 	backends map[string]framework.Framework
+	resolver Resolver
 }
 
+// Resolver finds the deployments referenced by the reference
+// fields of the input of a deployment and their secrets
+type Resolver interface {
+	GetDeploymentByIdOrPrefix(id string) (*proto.Deployment2, error)
+	GetSecret(deployment, name string) (string, error)
+}
+
+const (
+	// executionKind is the kind of the execution clients
+	executionKind = "execution"
+
+	// jwtSecretName is the name of the secret shared by an execution
+	// deployment and the consensus deployments that reference it
+	jwtSecretName = "jwt"
+)
+
 func NewCatalog() (*Catalog, error) {
 	c := &Catalog{
 		backends: map[string]framework.Framework{},
@@ -40,6 +60,12 @@ func (c *Catalog) SetLogger(logger hclog.Logger) {
 	c.logger = logger.Named("catalog")
 }
 
+// SetResolver sets the resolver of the reference fields. The
+// references are not resolved if there is no resolver.
+func (c *Catalog) SetResolver(resolver Resolver) {
+	c.resolver = resolver
+}
+
 func (c *Catalog) Load(path string) error {
 	fileInfo, err := os.Stat(path)
 	if err != nil {
@@ -107,10 +133,85 @@ func (c *Catalog) initBuiltin() error {
 	return nil
 }
 
-func (c *Catalog) Build(prev []byte, req *proto.ApplyRequest) ([]byte, map[string]*proto.Task, error) {
+// Build returns the state and the tasks of the deployment. It also returns
+// the secrets generated for the deployment, which are stored along with it.
+func (c *Catalog) Build(prev []byte, req *proto.ApplyRequest) ([]byte, map[string]*proto.Task, map[string]string, error) {
+	cc, prevMap, inputMap, err := c.decodeRequest(prev, req)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// validate the input and the state
+	state, data, err := processInput(cc.Config(), prevMap, inputMap)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	jwtSecret, generated, err := c.jwtSecret(cc, req, state)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	secrets := map[string]string{}
+	if generated {
+		secrets[jwtSecretName] = jwtSecret
+	}
+
+	config := &framework.Config{
+		Metrics:   req.GetMetrics(),
+		Chain:     req.Chain,
+		Data:      data,
+		JWTSecret: jwtSecret,
+	}
+
+	deployableTasks := cc.Generate(config)
+	if jwtSecret != "" {
+		markSecretData(deployableTasks, jwtSecret)
+	}
+
+	rawState, err := json.Marshal(state)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return rawState, deployableTasks, secrets, nil
+}
+
+// markSecretData records the data files of the tasks that contain
+// the secret so that they are not exposed by the API
+func markSecretData(tasks map[string]*proto.Task, secret string) {
+	for _, task := range tasks {
+		for path, value := range task.Data {
+			if strings.Contains(value, secret) {
+				task.SecretData = append(task.SecretData, path)
+			}
+		}
+		sort.Strings(task.SecretData)
+	}
+}
+
+// Diff returns the fields of the input that change with respect
+// to the previous state of the deployment.
+func (c *Catalog) Diff(prev []byte, req *proto.ApplyRequest) ([]*proto.Plan_FieldDiff, error) {
+	cc, prevMap, inputMap, err := c.decodeRequest(prev, req)
+	if err != nil {
+		return nil, err
+	}
+
+	inputData := &framework.FieldData{
+		Raw:    inputMap,
+		Schema: cc.Config(),
+	}
+	if err := inputData.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate input: %v", err)
+	}
+
+	return diffInput(cc.Config(), prevMap, inputMap), nil
+}
+
+func (c *Catalog) decodeRequest(prev []byte, req *proto.ApplyRequest) (framework.Framework, map[string]interface{}, map[string]interface{}, error) {
 	cc, ok := c.backends[strings.ToLower(req.Action)]
 	if !ok {
-		return nil, nil, fmt.Errorf("not found plugin: %s", req.Action)
+		return nil, nil, nil, fmt.Errorf("not found plugin: %s", req.Action)
 	}
 
 	// validate that the plugin can run this chain
@@ -122,19 +223,19 @@ func (c *Catalog) Build(prev []byte, req *proto.ApplyRequest) ([]byte, map[strin
 		}
 	}
 	if !found {
-		return nil, nil, fmt.Errorf("cannot run chain '%s'", req.Chain)
+		return nil, nil, nil, fmt.Errorf("cannot run chain '%s'", req.Chain)
 	}
 
 	var prevMap map[string]interface{}
 	if prev != nil {
 		if err := json.Unmarshal(prev, &prevMap); err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
 	var inputMap map[string]interface{}
 	if err := json.Unmarshal(req.Input, &inputMap); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	// add to input the typed parameters from the request
@@ -142,26 +243,164 @@ func (c *Catalog) Build(prev []byte, req *proto.ApplyRequest) ([]byte, map[strin
 		inputMap["log_level"] = req.LogLevel
 	}
 
-	// validate the input and the state
-	state, data, err := processInput(cc.Config(), prevMap, inputMap)
-	if err != nil {
-		return nil, nil, err
+	if err := c.resolveReferences(cc.Config(), req.Chain, inputMap); err != nil {
+		return nil, nil, nil, err
 	}
 
-	config := &framework.Config{
-		Metrics: req.Metrics,
-		Chain:   req.Chain,
-		Data:    data,
+	return cc, prevMap, inputMap, nil
+}
+
+// resolveReferences replaces the values of the reference fields of the input
+// with the id of the referenced deployment, which is also its DNS name in the
+// network of the deployments.
+func (c *Catalog) resolveReferences(fields map[string]*framework.Field, chain string, input map[string]interface{}) error {
+	if c.resolver == nil {
+		return nil
+	}
+	for name, field := range fields {
+		if field.Type != framework.TypeReference {
+			continue
+		}
+		raw, ok := input[name]
+		if !ok {
+			continue
+		}
+		ref, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("field '%s' must be the id or the alias of a deployment", name)
+		}
+
+		dep, err := c.resolver.GetDeploymentByIdOrPrefix(ref)
+		if err != nil {
+			return fmt.Errorf("field '%s' references an unknown deployment: %v", name, err)
+		}
+		if dep.Status == proto.Deployment2_Destroyed {
+			return fmt.Errorf("field '%s' references the destroyed deployment '%s'", name, dep.Id)
+		}
+		if dep.Chain != chain {
+			return fmt.Errorf("field '%s' references deployment '%s' of chain '%s'", name, dep.Id, dep.Chain)
+		}
+		if field.Kind != "" {
+			if cc, ok := c.backends[strings.ToLower(dep.Action)]; !ok || cc.Kind() != field.Kind {
+				return fmt.Errorf("field '%s' references deployment '%s' of plugin '%s' which is not of kind '%s'", name, dep.Id, dep.Action, field.Kind)
+			}
+		}
+		input[name] = dep.Id
 	}
+	return nil
+}
 
-	deployableTasks := cc.Generate(config)
+// jwtSecret returns the JWT secret of the deployment of the request if it is
+// an execution client or the one of the execution deployment that it
+// references. The secret of an execution deployment is generated the first
+// time it is built, in which case generated is true and the caller stores it
+// along with the deployment.
+func (c *Catalog) jwtSecret(cc framework.Framework, req *proto.ApplyRequest, state map[string]interface{}) (string, bool, error) {
+	if c.resolver == nil {
+		return "", false, nil
+	}
 
-	rawState, err := json.Marshal(state)
-	if err != nil {
-		return nil, nil, err
+	if cc.Kind() == executionKind {
+		if req.AllocationId != "" {
+			secret, err := c.resolver.GetSecret(req.AllocationId, jwtSecretName)
+			if err != nil {
+				return "", false, err
+			}
+			if secret != "" {
+				return secret, false, nil
+			}
+		}
+
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return "", false, err
+		}
+		return hex.EncodeToString(buf), true, nil
+	}
+
+	for name, field := range cc.Config() {
+		if field.Type != framework.TypeReference || field.Kind != executionKind {
+			continue
+		}
+		ref, ok := state[name].(string)
+		if !ok || ref == "" {
+			continue
+		}
+
+		secret, err := c.resolver.GetSecret(ref, jwtSecretName)
+		if err != nil {
+			return "", false, err
+		}
+		if secret == "" {
+			return "", false, fmt.Errorf("deployment '%s' referenced by field '%s' has no JWT secret, update it to generate one", ref, name)
+		}
+		return secret, false, nil
+	}
+	return "", false, nil
+}
+
+// References returns the ids of the deployments referenced
+// by the state of a deployment of the plugin.
+func (c *Catalog) References(plugin string, spec []byte) ([]string, error) {
+	cc, ok := c.backends[strings.ToLower(plugin)]
+	if !ok {
+		return nil, fmt.Errorf("not found plugin: %s", plugin)
+	}
+
+	var state map[string]interface{}
+	if err := json.Unmarshal(spec, &state); err != nil {
+		return nil, err
+	}
+
+	refs := []string{}
+	for name, field := range cc.Config() {
+		if field.Type != framework.TypeReference {
+			continue
+		}
+		if ref, ok := state[name].(string); ok && ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	sort.Strings(refs)
+
+	return refs, nil
+}
+
+// diffInput compares the values of the input with the ones in the state.
+// If there is no state (i.e. new deployment) every input value is a change.
+func diffInput(fields map[string]*framework.Field, state map[string]interface{}, input map[string]interface{}) []*proto.Plan_FieldDiff {
+	inputData := &framework.FieldData{
+		Raw:    input,
+		Schema: fields,
+	}
+	stateData := &framework.FieldData{
+		Raw:    state,
+		Schema: fields,
 	}
 
-	return rawState, deployableTasks, nil
+	diffs := []*proto.Plan_FieldDiff{}
+	for k := range input {
+		newVal := inputData.Get(k)
+
+		diff := &proto.Plan_FieldDiff{
+			Name: k,
+			New:  fmt.Sprintf("%v", newVal),
+		}
+		if state != nil {
+			oldVal := stateData.Get(k)
+			if oldVal == newVal {
+				continue
+			}
+			diff.Old = fmt.Sprintf("%v", oldVal)
+			diff.ForceNew = fields[k].ForceNew
+		}
+		diffs = append(diffs, diff)
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
 }
 
 func processInput(fields map[string]*framework.Field, state map[string]interface{}, input map[string]interface{}) (map[string]interface{}, *framework.FieldData, error) {
@@ -176,18 +415,9 @@ func processInput(fields map[string]*framework.Field, state map[string]interface
 
 	if state != nil {
 		// validate that any new value is not a forceNew field
-		stateData := &framework.FieldData{
-			Raw:    state,
-			Schema: fields,
-		}
-		for k := range input {
-			if fields[k].ForceNew {
-				oldVal := stateData.Get(k)
-				newVal := inputData.Get(k)
-
-				if newVal != oldVal {
-					return nil, nil, fmt.Errorf("force new value '%s' has changed", k)
-				}
+		for _, diff := range diffInput(fields, state, input) {
+			if diff.ForceNew {
+				return nil, nil, fmt.Errorf("force new value '%s' has changed", diff.Name)
 			}
 		}
 
@@ -241,9 +471,11 @@ func (c *Catalog) GetPlugin(name string) (*proto.Item, error) {
 	}
 
 	item := &proto.Item{
-		Name:   name,
-		Fields: []*proto.Item_Field{},
-		Chains: pl.Chains(),
+		Name:     name,
+		Fields:   []*proto.Item_Field{},
+		Chains:   pl.Chains(),
+		Version:  pl.Version(),
+		DiskSize: pl.DiskSize(),
 	}
 	for name, field := range cfg {
 		item.Fields = append(item.Fields, &proto.Item_Field{
@@ -1,10 +1,13 @@
 package catalog
 
 import (
+	"encoding/json"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/umbracle/vesta/internal/framework"
+	"github.com/umbracle/vesta/internal/server/proto"
 )
 
 func TestBuiltin_Images(t *testing.T) {
@@ -122,3 +125,191 @@ func TestCatalog_ProcessInput(t *testing.T) {
 		}
 	}
 }
+
+func TestCatalog_DiffInput(t *testing.T) {
+	fields := map[string]*framework.Field{
+		"a": {
+			Type: framework.TypeString,
+		},
+		"b": {
+			Type:     framework.TypeString,
+			ForceNew: true,
+			Default:  "x",
+		},
+		"c": {
+			Type: framework.TypeInt,
+		},
+	}
+
+	// new deployment, every input is a change and
+	// force new values do not apply
+	diffs := diffInput(fields, nil, map[string]interface{}{"a": "x", "b": "y"})
+	require.Len(t, diffs, 2)
+	require.Equal(t, "a", diffs[0].Name)
+	require.Equal(t, "x", diffs[0].New)
+	require.False(t, diffs[1].ForceNew)
+
+	// update, only the changed values are returned
+	state := map[string]interface{}{"a": "x", "c": 1}
+	diffs = diffInput(fields, state, map[string]interface{}{"a": "x", "b": "y", "c": 2})
+	require.Len(t, diffs, 2)
+
+	require.Equal(t, "b", diffs[0].Name)
+	require.Equal(t, "x", diffs[0].Old)
+	require.Equal(t, "y", diffs[0].New)
+	require.True(t, diffs[0].ForceNew)
+
+	require.Equal(t, "c", diffs[1].Name)
+	require.Equal(t, "1", diffs[1].Old)
+	require.Equal(t, "2", diffs[1].New)
+	require.False(t, diffs[1].ForceNew)
+}
+
+func TestBackend_DiskSize(t *testing.T) {
+	content := `
+name = "test"
+
+chains = ["mainnet", "goerli"]
+
+config = {}
+
+disk_size = {"mainnet": "1TB", "goerli": "250GB"}
+`
+	backend := newBackend([]byte(content))
+
+	diskSize := backend.DiskSize()
+	require.Equal(t, uint64(1000*1000*1000*1000), diskSize["mainnet"])
+	require.Equal(t, uint64(250*1000*1000*1000), diskSize["goerli"])
+}
+
+type mockResolver struct {
+	deployments []*proto.Deployment2
+	secrets     map[string]string
+}
+
+func (m *mockResolver) GetDeploymentByIdOrPrefix(id string) (*proto.Deployment2, error) {
+	for _, dep := range m.deployments {
+		if dep.Id == id || dep.Alias == id {
+			return dep, nil
+		}
+	}
+	return nil, fmt.Errorf("no deployment found with id or prefix '%s'", id)
+}
+
+func (m *mockResolver) GetSecret(deployment, name string) (string, error) {
+	return m.secrets[deployment+"/"+name], nil
+}
+
+func TestCatalog_References(t *testing.T) {
+	catalog, err := NewCatalog()
+	require.NoError(t, err)
+
+	catalog.SetResolver(&mockResolver{
+		deployments: []*proto.Deployment2{
+			{Id: "a", Alias: "el", Action: "geth", Chain: "mainnet"},
+			{Id: "b", Action: "prysm", Chain: "mainnet"},
+			{Id: "c", Action: "geth", Chain: "goerli"},
+			{Id: "d", Action: "geth", Chain: "mainnet", Status: proto.Deployment2_Destroyed},
+		},
+		secrets: map[string]string{
+			"a/jwt": "secret",
+		},
+	})
+
+	build := func(ref string) ([]byte, map[string]*proto.Task, error) {
+		input, err := json.Marshal(map[string]interface{}{"execution_node": ref})
+		require.NoError(t, err)
+
+		state, tasks, _, err := catalog.Build(nil, &proto.ApplyRequest{Action: "prysm", Chain: "mainnet", Input: input})
+		return state, tasks, err
+	}
+
+	// dangling reference
+	_, _, err = build("e")
+	require.Error(t, err)
+
+	// the deployment is not an execution client
+	_, _, err = build("b")
+	require.Error(t, err)
+
+	// the deployment runs on another chain
+	_, _, err = build("c")
+	require.Error(t, err)
+
+	// the deployment is destroyed
+	_, _, err = build("d")
+	require.Error(t, err)
+
+	// the alias resolves to the id of the deployment
+	state, tasks, err := build("el")
+	require.NoError(t, err)
+	require.Contains(t, tasks["node"].Args, "http://a:8551")
+
+	refs, err := catalog.References("prysm", state)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, refs)
+
+	refs, err = catalog.References("geth", []byte("{}"))
+	require.NoError(t, err)
+	require.Empty(t, refs)
+}
+
+func TestCatalog_JWTSecret(t *testing.T) {
+	catalog, err := NewCatalog()
+	require.NoError(t, err)
+
+	resolver := &mockResolver{
+		secrets: map[string]string{},
+	}
+	catalog.SetResolver(resolver)
+
+	jwtPath := "/var/lib/jwtsecret/jwt.hex"
+	jwtSecret := func(tasks map[string]*proto.Task) string {
+		return tasks["node"].Data[jwtPath]
+	}
+
+	build := func(req *proto.ApplyRequest) (map[string]*proto.Task, map[string]string) {
+		req.Chain = "mainnet"
+		if req.Input == nil {
+			req.Input = []byte("{}")
+		}
+		_, tasks, secrets, err := catalog.Build(nil, req)
+		require.NoError(t, err)
+		return tasks, secrets
+	}
+
+	// the secret of the execution deployment is generated and
+	// returned to be stored with the deployment
+	tasks, secrets := build(&proto.ApplyRequest{Action: "geth", AllocationId: "a"})
+	secret := jwtSecret(tasks)
+	require.Len(t, secret, 64)
+	require.Equal(t, map[string]string{"jwt": secret}, secrets)
+
+	// the data file with the secret is marked
+	require.Equal(t, []string{jwtPath}, tasks["node"].SecretData)
+
+	// the stored secret is used once it exists
+	resolver.secrets["a/jwt"] = secret
+
+	tasks, secrets = build(&proto.ApplyRequest{Action: "geth", AllocationId: "a"})
+	require.Equal(t, secret, jwtSecret(tasks))
+	require.Empty(t, secrets)
+
+	// each execution deployment has its own secret
+	tasks, _ = build(&proto.ApplyRequest{Action: "besu", AllocationId: "b"})
+	require.NotEqual(t, secret, jwtSecret(tasks))
+
+	// the consensus deployment uses the secret of the execution deployment
+	resolver.deployments = []*proto.Deployment2{
+		{Id: "a", Action: "geth", Chain: "mainnet"},
+		{Id: "c", Action: "nethermind", Chain: "mainnet"},
+	}
+	tasks, secrets = build(&proto.ApplyRequest{Action: "teku", AllocationId: "d", Input: []byte(`{"execution_node": "a"}`)})
+	require.Equal(t, secret, jwtSecret(tasks))
+	require.Equal(t, []string{jwtPath}, tasks["node"].SecretData)
+	require.Empty(t, secrets)
+
+	// the execution deployment does not have a secret yet
+	_, _, _, err = catalog.Build(nil, &proto.ApplyRequest{Action: "teku", Chain: "mainnet", Input: []byte(`{"execution_node": "c"}`)})
+	require.Error(t, err)
+}
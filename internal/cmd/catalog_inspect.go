@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/docker/go-units"
 	"github.com/umbracle/vesta/internal/server/proto"
 )
 
@@ -59,6 +60,7 @@ func (c *CatalogInspectCommand) Run(args []string) int {
 func formatItem(item *proto.Item) string {
 	base := formatKV([]string{
 		fmt.Sprintf("Name|%s", item.Name),
+		fmt.Sprintf("Version|%s", item.Version),
 	})
 
 	taskRows := make([]string, len(item.Fields)+1)
@@ -79,12 +81,17 @@ func formatItem(item *proto.Item) string {
 	base += formatList(taskRows)
 
 	chainRows := make([]string, len(item.Chains)+1)
-	chainRows[0] = "Name"
+	chainRows[0] = "Name|Disk Size"
 
 	i = 1
 	for _, name := range item.Chains {
-		chainRows[i] = fmt.Sprintf("%s",
+		diskSize := "-"
+		if size, ok := item.DiskSize[name]; ok {
+			diskSize = units.HumanSize(float64(size))
+		}
+		chainRows[i] = fmt.Sprintf("%s|%s",
 			name,
+			diskSize,
 		)
 		i += 1
 	}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"time"
 
 	flag "github.com/spf13/pflag"
 	"golang.org/x/crypto/ssh/terminal"
@@ -32,6 +33,11 @@ func Commands() map[string]cli.CommandFactory {
 				UI: ui,
 			}, nil
 		},
+		"server migrate": func() (cli.Command, error) {
+			return &ServerMigrateCommand{
+				UI: ui,
+			}, nil
+		},
 		"catalog": func() (cli.Command, error) {
 			return &CatalogCommand{
 				Meta: meta,
@@ -62,6 +68,21 @@ func Commands() map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"deployment events": func() (cli.Command, error) {
+			return &DeploymentEventsCommand{
+				Meta: meta,
+			}, nil
+		},
+		"deployment history": func() (cli.Command, error) {
+			return &DeploymentHistoryCommand{
+				Meta: meta,
+			}, nil
+		},
+		"deployment rollback": func() (cli.Command, error) {
+			return &DeploymentRollbackCommand{
+				Meta: meta,
+			}, nil
+		},
 		"destroy": func() (cli.Command, error) {
 			return &DestroyCommand{
 				Meta: meta,
@@ -72,6 +93,36 @@ func Commands() map[string]cli.CommandFactory {
 				Meta: meta,
 			}, nil
 		},
+		"exec": func() (cli.Command, error) {
+			return &ExecCommand{
+				Meta: meta,
+			}, nil
+		},
+		"logs": func() (cli.Command, error) {
+			return &LogsCommand{
+				Meta: meta,
+			}, nil
+		},
+		"volume": func() (cli.Command, error) {
+			return &VolumeCommand{
+				Meta: meta,
+			}, nil
+		},
+		"volume snapshot": func() (cli.Command, error) {
+			return &VolumeSnapshotCommand{
+				Meta: meta,
+			}, nil
+		},
+		"volume restore": func() (cli.Command, error) {
+			return &VolumeRestoreCommand{
+				Meta: meta,
+			}, nil
+		},
+		"volume ls": func() (cli.Command, error) {
+			return &VolumeListCommand{
+				Meta: meta,
+			}, nil
+		},
 		"version": func() (cli.Command, error) {
 			return &VersionCommand{
 				UI: ui,
@@ -114,6 +165,23 @@ func formatKV(in []string) string {
 	return columnize.Format(in, columnConf)
 }
 
+// formatTimeAgo returns the time elapsed since t (i.e. 5m ago)
+func formatTimeAgo(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Second:
+		return "just now"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
 func (m *Meta) Colorize() *colorstring.Colorize {
 	return &colorstring.Colorize{
 		Colors:  colorstring.DefaultColors,
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"sort"
 	"strings"
 
 	"github.com/umbracle/vesta/internal/server/proto"
@@ -27,6 +28,12 @@ type DeployCommand struct {
 	alias string
 
 	logLevel string
+
+	plan bool
+
+	fromDeployment string
+
+	fromSnapshot string
 }
 
 // Help implements the cli.Command interface
@@ -51,6 +58,9 @@ func (c *DeployCommand) Run(args []string) int {
 	flags.BoolVar(&c.metrics, "metrics", true, "")
 	flags.StringVar(&c.alias, "alias", "", "")
 	flags.StringVar(&c.logLevel, "log-level", "info", "")
+	flags.BoolVar(&c.plan, "plan", false, "")
+	flags.StringVar(&c.fromDeployment, "from-deployment", "", "")
+	flags.StringVar(&c.fromSnapshot, "from-snapshot", "", "")
 
 	if err := flags.Parse(args); err != nil {
 		c.UI.Error(err.Error())
@@ -93,19 +103,99 @@ func (c *DeployCommand) Run(args []string) int {
 		return 1
 	}
 	req := &proto.ApplyRequest{
-		Action:       c.typ,
-		Input:        raw,
-		AllocationId: c.allocId,
-		Chain:        c.chain,
-		Metrics:      c.metrics,
-		Alias:        c.alias,
-		LogLevel:     c.logLevel,
+		Action:         c.typ,
+		Input:          raw,
+		AllocationId:   c.allocId,
+		Chain:          c.chain,
+		Alias:          c.alias,
+		DryRun:         c.plan,
+		FromDeployment: c.fromDeployment,
+		FromSnapshot:   c.fromSnapshot,
+	}
+	if flags.Changed("metrics") {
+		// on updates, metrics keeps its value unless it is set
+		req.Metrics = &c.metrics
+	}
+	if c.allocId == "" || flags.Changed("log-level") {
+		// on updates, the log level keeps its value unless it is set
+		req.LogLevel = c.logLevel
 	}
 	resp, err := clt.Apply(context.Background(), req)
 	if err != nil {
 		c.UI.Error(err.Error())
 		return 1
 	}
+	if c.plan {
+		c.UI.Output(formatPlan(resp.Plan, resp.Tasks))
+		if resp.Plan.Blocked {
+			c.UI.Error("The change is blocked by a force_new field")
+			return 1
+		}
+		return 0
+	}
+
 	c.UI.Output(resp.Id)
+	if len(resp.Tasks) != 0 {
+		c.UI.Output("")
+		c.UI.Output(formatTaskDiffs(resp.Tasks))
+	}
 	return 0
 }
+
+func formatPlan(plan *proto.Plan, diffs []*proto.TaskDiff) string {
+	out := "Input changes\n"
+	if len(plan.Fields) == 0 {
+		out += "No changes\n"
+	} else {
+		rows := make([]string, len(plan.Fields)+1)
+		rows[0] = "Field|Old|New|Force new"
+		for i, f := range plan.Fields {
+			rows[i+1] = fmt.Sprintf("%s|%s|%s|%v",
+				f.Name,
+				f.Old,
+				f.New,
+				f.ForceNew,
+			)
+		}
+		out += formatList(rows) + "\n"
+	}
+
+	if plan.Blocked {
+		return out
+	}
+
+	names := []string{}
+	for name := range plan.Tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([]string, len(names)+1)
+	rows[0] = "Task|Image|Args"
+	for i, name := range names {
+		task := plan.Tasks[name]
+		rows[i+1] = fmt.Sprintf("%s|%s:%s|%s",
+			name,
+			task.Image,
+			task.Tag,
+			strings.Join(task.Args, " "),
+		)
+	}
+	out += "\nTasks\n" + formatList(rows) + "\n"
+	out += "\nTask changes\n" + formatTaskDiffs(diffs)
+
+	return out
+}
+
+func formatTaskDiffs(diffs []*proto.TaskDiff) string {
+	rows := make([]string, len(diffs)+1)
+	rows[0] = "Task|Change|Fields"
+	for i, d := range diffs {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s",
+			d.Name,
+			d.Type,
+			strings.Join(d.Fields, ","),
+		)
+	}
+	return formatList(rows)
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+// DeploymentEventsCommand is the command to output the events of a deployment
+type DeploymentEventsCommand struct {
+	*Meta
+
+	follow bool
+	task   string
+	types  []string
+	index  uint64
+}
+
+// Help implements the cli.Command interface
+func (c *DeploymentEventsCommand) Help() string {
+	return `Usage: vesta deployment events [options] <id>
+
+  Output the events of a deployment`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DeploymentEventsCommand) Synopsis() string {
+	return "Output the events of a deployment"
+}
+
+// Run implements the cli.Command interface
+func (c *DeploymentEventsCommand) Run(args []string) int {
+	flags := c.FlagSet("deployment events")
+	flags.BoolVar(&c.follow, "follow", false, "")
+	flags.StringVar(&c.task, "task", "", "")
+	flags.StringSliceVar(&c.types, "type", nil, "")
+	flags.Uint64Var(&c.index, "index", 0, "")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("incorrect input, provide one argument")
+		return 1
+	}
+
+	client, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	req := &proto.WatchEventsRequest{
+		Deployment: args[0],
+		Task:       c.task,
+		Types:      c.types,
+		Index:      c.index,
+		Follow:     c.follow,
+	}
+	stream, err := client.WatchEvents(ctx, req)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return 0
+		}
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+		c.UI.Output(formatEvent(resp.Event))
+	}
+}
+
+func formatEvent(event *proto.Event2) string {
+	timestamp := time.Unix(0, event.Timestamp).Format(time.RFC3339)
+	return fmt.Sprintf("%-6d %s %-12s %-12s %-8s %s", event.Index, timestamp, event.Task, event.Type, event.Severity, event.Message)
+}
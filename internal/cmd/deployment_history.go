@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+// DeploymentHistoryCommand is the command to list the revisions of a deployment
+type DeploymentHistoryCommand struct {
+	*Meta
+}
+
+// Help implements the cli.Command interface
+func (c *DeploymentHistoryCommand) Help() string {
+	return `Usage: vesta deployment history <id>
+
+  Output the revisions of a deployment`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DeploymentHistoryCommand) Synopsis() string {
+	return "Output the revisions of a deployment"
+}
+
+// Run implements the cli.Command interface
+func (c *DeploymentHistoryCommand) Run(args []string) int {
+	flags := c.FlagSet("deployment history")
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("incorrect input, provide one argument")
+		return 1
+	}
+
+	client, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	resp, err := client.DeploymentHistory(context.Background(), &proto.DeploymentHistoryRequest{Id: args[0]})
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatRevisions(resp.Revisions))
+	return 0
+}
+
+func formatRevisions(revisions []*proto.DeploymentRevision) string {
+	if len(revisions) == 0 {
+		return "No revisions found"
+	}
+
+	rows := make([]string, len(revisions)+1)
+	rows[0] = "Revision|Plugin|Version|Tasks|Created"
+	for i, r := range revisions {
+		rows[i+1] = fmt.Sprintf("%d|%s|%s|%d|%s",
+			r.Revision,
+			r.Plugin,
+			r.PluginVersion,
+			len(r.Tasks),
+			time.Unix(r.CreatedAt, 0).Format(time.RFC3339),
+		)
+	}
+	return formatList(rows)
+}
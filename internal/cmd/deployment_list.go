@@ -54,10 +54,14 @@ func formatNodes(allocs []*proto.Deployment2) string {
 	}
 
 	rows := make([]string, len(allocs)+1)
-	rows[0] = "ID"
+	rows[0] = "ID|Alias|Plugin|Chain|Status"
 	for i, d := range allocs {
-		rows[i+1] = fmt.Sprintf("%s",
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s",
 			d.Id,
+			d.Alias,
+			d.Action,
+			d.Chain,
+			d.Status,
 		)
 	}
 	return formatList(rows)
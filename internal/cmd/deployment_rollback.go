@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+// DeploymentRollbackCommand is the command to roll back a deployment to a previous revision
+type DeploymentRollbackCommand struct {
+	*Meta
+
+	revision uint64
+}
+
+// Help implements the cli.Command interface
+func (c *DeploymentRollbackCommand) Help() string {
+	return `Usage: vesta deployment rollback [options] <id>
+
+  Roll back a deployment to a previous revision`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *DeploymentRollbackCommand) Synopsis() string {
+	return "Roll back a deployment to a previous revision"
+}
+
+// Run implements the cli.Command interface
+func (c *DeploymentRollbackCommand) Run(args []string) int {
+	flags := c.FlagSet("deployment rollback")
+	flags.Uint64Var(&c.revision, "revision", 0, "")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 {
+		c.UI.Error("incorrect input, provide one argument")
+		return 1
+	}
+	if c.revision == 0 {
+		c.UI.Error("the revision to roll back to is required")
+		return 1
+	}
+
+	client, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	req := &proto.DeploymentRollbackRequest{
+		Id:       args[0],
+		Revision: c.revision,
+	}
+	resp, err := client.DeploymentRollback(context.Background(), req)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Deployment rolled back to revision %d (new revision %d)", c.revision, resp.Revision))
+	if len(resp.Tasks) != 0 {
+		c.UI.Output("")
+		c.UI.Output(formatTaskDiffs(resp.Tasks))
+	}
+	return 0
+}
@@ -3,7 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/docker/go-units"
 	"github.com/umbracle/vesta/internal/server/proto"
 )
 
@@ -56,23 +58,47 @@ func (c *DeploymentStatusCommand) Run(args []string) int {
 	return 0
 }
 
+// formatDeploymentStatus returns the status of the deployment
+// along with the reason if it is stopped
+func formatDeploymentStatus(dep *proto.Deployment2) string {
+	if dep.Status == proto.Deployment2_Stopped && dep.StopReason != "" {
+		return fmt.Sprintf("%s (%s)", dep.Status, dep.StopReason)
+	}
+	return dep.Status.String()
+}
+
 func formatNodeStatus(r *proto.DeploymentStatusResponse) string {
 	node := r.Allocation
 
 	base := formatKV([]string{
 		fmt.Sprintf("ID|%s", node.Id),
 		fmt.Sprintf("Name|%s", node.Name),
+		fmt.Sprintf("Alias|%s", node.Alias),
+		fmt.Sprintf("Plugin|%s", node.Action),
+		fmt.Sprintf("Chain|%s", node.Chain),
+		fmt.Sprintf("Metrics|%v", node.Metrics),
+		fmt.Sprintf("Status|%s", formatDeploymentStatus(node)),
+		fmt.Sprintf("Health|%s", r.Health),
+		fmt.Sprintf("Disk Usage|%s", formatDiskUsage(r.DiskUsage, r.ExpectedDiskSize)),
 	})
 
+	if len(r.Tasks) != 0 {
+		base += "\n\n[bold]Tasks[reset]\n"
+		base += formatTaskStates(r.Tasks)
+	}
+
 	taskRows := make([]string, len(r.Events)+1)
-	taskRows[0] = "ID|Task|Type"
+	taskRows[0] = "Index|Time|Task|Type|Severity|Message"
 
 	i := 1
 	for _, d := range r.Events {
-		taskRows[i] = fmt.Sprintf("%s|%s|%s",
-			d.Id,
+		taskRows[i] = fmt.Sprintf("%d|%s|%s|%s|%s|%s",
+			d.Index,
+			formatTimeAgo(time.Unix(0, d.Timestamp)),
 			d.Task,
 			d.Type,
+			d.Severity,
+			d.Message,
 		)
 		i += 1
 	}
@@ -82,3 +108,32 @@ func formatNodeStatus(r *proto.DeploymentStatusResponse) string {
 
 	return base
 }
+
+func formatDiskUsage(usage, expected uint64) string {
+	str := units.HumanSize(float64(usage))
+	if expected != 0 {
+		str += fmt.Sprintf(" (expected %s)", units.HumanSize(float64(expected)))
+	}
+	return str
+}
+
+func formatTaskStates(states []*proto.TaskState2) string {
+	rows := make([]string, len(states)+1)
+	rows[0] = "Name|State|Restarts|Exit Code|Started|Error"
+
+	for i, state := range states {
+		started := "-"
+		if state.StartedAt != 0 {
+			started = formatTimeAgo(time.Unix(state.StartedAt, 0))
+		}
+		rows[i+1] = fmt.Sprintf("%s|%s|%d|%d|%s|%s",
+			state.Name,
+			state.State,
+			state.Restarts,
+			state.ExitCode,
+			started,
+			state.Error,
+		)
+	}
+	return formatList(rows)
+}
@@ -10,11 +10,13 @@ import (
 // DestroyCommand is the command to destroy an allocation
 type DestroyCommand struct {
 	*Meta
+
+	purgeVolumes bool
 }
 
 // Help implements the cli.Command interface
 func (c *DestroyCommand) Help() string {
-	return `Usage: vesta destroy
+	return `Usage: vesta destroy [options] <id>
 	
   Destroy a deployment`
 }
@@ -27,6 +29,7 @@ func (c *DestroyCommand) Synopsis() string {
 // Run implements the cli.Command interface
 func (c *DestroyCommand) Run(args []string) int {
 	flags := c.FlagSet("destroy")
+	flags.BoolVar(&c.purgeVolumes, "purge-volumes", false, "")
 
 	if err := flags.Parse(args); err != nil {
 		c.UI.Error(err.Error())
@@ -46,14 +49,13 @@ func (c *DestroyCommand) Run(args []string) int {
 	}
 
 	req := &proto.DestroyRequest{
-		Id: args[0],
+		Id:           args[0],
+		PurgeVolumes: c.purgeVolumes,
 	}
-	resp, err := clt.Destroy(context.Background(), req)
-	if err != nil {
+	if _, err := clt.Destroy(context.Background(), req); err != nil {
 		c.UI.Error(err.Error())
 		return 1
 	}
-	fmt.Println(resp)
 
 	return 0
 }
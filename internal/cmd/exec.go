@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+
+	"github.com/umbracle/vesta/internal/server/proto"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// ExecCommand is the command to run a command inside a task
+type ExecCommand struct {
+	*Meta
+
+	tty         bool
+	interactive bool
+}
+
+// Help implements the cli.Command interface
+func (c *ExecCommand) Help() string {
+	return `Usage: vesta exec [options] <deployment> <task> -- <command> [args...]
+
+  Run a command inside a running task of a deployment`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ExecCommand) Synopsis() string {
+	return "Run a command inside a running task"
+}
+
+// Run implements the cli.Command interface
+func (c *ExecCommand) Run(args []string) int {
+	flags := c.FlagSet("exec")
+	flags.BoolVar(&c.tty, "tty", false, "")
+	flags.BoolVar(&c.interactive, "interactive", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) < 3 {
+		c.UI.Error("incorrect input, provide the deployment, the task and the command")
+		return 1
+	}
+
+	setup := &proto.TaskExecRequest_Setup{
+		Deployment: args[0],
+		Task:       args[1],
+		Cmd:        args[2:],
+		Tty:        c.tty,
+	}
+
+	stdinFd := int(os.Stdin.Fd())
+	isTerminal := c.tty && terminal.IsTerminal(stdinFd)
+	if isTerminal {
+		if width, height, err := terminal.GetSize(stdinFd); err == nil {
+			setup.Size = &proto.TaskExecRequest_TerminalSize{Height: uint32(height), Width: uint32(width)}
+		}
+	}
+
+	client, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := client.TaskExec(ctx)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if err := stream.Send(&proto.TaskExecRequest{Setup: setup}); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if isTerminal {
+		// forward every key (i.e. ctrl-c) to the command
+		state, err := terminal.MakeRaw(stdinFd)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+		defer terminal.Restore(stdinFd, state)
+	}
+
+	// the stream can only be written from a single goroutine
+	go func() {
+		if !c.interactive {
+			stream.Send(&proto.TaskExecRequest{StdinClose: true})
+			return
+		}
+
+		inputCh := make(chan []byte)
+		go func() {
+			defer close(inputCh)
+
+			buf := make([]byte, 4096)
+			for {
+				n, err := os.Stdin.Read(buf)
+				if n > 0 {
+					inputCh <- append([]byte{}, buf[:n]...)
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+
+		resizeCh := make(chan os.Signal, 1)
+		if isTerminal {
+			notifyResize(resizeCh)
+			defer signal.Stop(resizeCh)
+		}
+
+		for {
+			select {
+			case data, ok := <-inputCh:
+				if !ok {
+					stream.Send(&proto.TaskExecRequest{StdinClose: true})
+					return
+				}
+				if err := stream.Send(&proto.TaskExecRequest{Stdin: data}); err != nil {
+					return
+				}
+
+			case <-resizeCh:
+				width, height, err := terminal.GetSize(stdinFd)
+				if err != nil {
+					continue
+				}
+				size := &proto.TaskExecRequest_TerminalSize{Height: uint32(height), Width: uint32(width)}
+				if err := stream.Send(&proto.TaskExecRequest{Resize: size}); err != nil {
+					return
+				}
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return 0
+		}
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+		if resp.Exited {
+			return int(resp.ExitCode)
+		}
+		os.Stdout.Write(resp.Stdout)
+		os.Stderr.Write(resp.Stderr)
+	}
+}
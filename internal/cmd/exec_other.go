@@ -0,0 +1,10 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package cmd
+
+import "os"
+
+// notifyResize is a no-op on the platforms without SIGWINCH, the
+// terminal keeps the size it had when the session started
+func notifyResize(ch chan os.Signal) {
+}
@@ -0,0 +1,14 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// notifyResize sends to the channel the resizes of the terminal
+func notifyResize(ch chan os.Signal) {
+	signal.Notify(ch, syscall.SIGWINCH)
+}
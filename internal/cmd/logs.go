@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+// LogsCommand is the command to output the logs of a task
+type LogsCommand struct {
+	*Meta
+
+	follow bool
+	tail   string
+	since  string
+}
+
+// Help implements the cli.Command interface
+func (c *LogsCommand) Help() string {
+	return `Usage: vesta logs [options] <deployment> <task>
+
+  Output the logs of a task of a deployment`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *LogsCommand) Synopsis() string {
+	return "Output the logs of a task"
+}
+
+// Run implements the cli.Command interface
+func (c *LogsCommand) Run(args []string) int {
+	flags := c.FlagSet("logs")
+	flags.BoolVar(&c.follow, "follow", false, "")
+	flags.StringVar(&c.tail, "tail", "", "")
+	flags.StringVar(&c.since, "since", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 2 {
+		c.UI.Error("incorrect input, provide the deployment and the task")
+		return 1
+	}
+
+	req := &proto.TaskLogsRequest{
+		Deployment: args[0],
+		Task:       args[1],
+		Follow:     c.follow,
+		Tail:       c.tail,
+	}
+	if c.since != "" {
+		since, err := parseSince(c.since)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+		req.Since = since.Unix()
+	}
+
+	client, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	stream, err := client.TaskLogs(ctx, req)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF || ctx.Err() != nil {
+			return 0
+		}
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+
+		w := os.Stdout
+		if resp.Stream == proto.TaskLogsResponse_Stderr {
+			w = os.Stderr
+		}
+		w.Write(resp.Data)
+	}
+}
+
+// parseSince parses either a relative duration (i.e. 10m) or an
+// RFC3339 timestamp
+func parseSince(since string) (time.Time, error) {
+	if d, err := time.ParseDuration(since); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("incorrect since value '%s', use a duration or an RFC3339 timestamp", since)
+	}
+	return t, nil
+}
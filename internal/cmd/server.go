@@ -7,10 +7,10 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/boltdb/bolt"
 	"github.com/hashicorp/go-hclog"
 	"github.com/mitchellh/cli"
 	flag "github.com/spf13/pflag"
+	"github.com/umbracle/vesta/internal/backend"
 	"github.com/umbracle/vesta/internal/server"
 )
 
@@ -19,9 +19,18 @@ type ServerCommand struct {
 	UI     cli.Ui
 	server *server.Server
 
-	logLevel string
-	volume   string
-	catalog  []string
+	logLevel     string
+	dataDir      string
+	catalog      []string
+	driver       string
+	podmanSocket string
+	metricsAddr  string
+
+	eventMaxAge           time.Duration
+	eventMaxPerDeployment int
+
+	diskWarnThreshold float64
+	diskStopThreshold float64
 }
 
 // Help implements the cli.Command interface
@@ -40,8 +49,17 @@ func (c *ServerCommand) Synopsis() string {
 func (c *ServerCommand) Run(args []string) int {
 	flags := flag.NewFlagSet("server", flag.ContinueOnError)
 	flags.StringVar(&c.logLevel, "log-level", "info", "")
-	flags.StringVar(&c.volume, "volume", "", "")
 	flags.StringSliceVar(&c.catalog, "catalog", []string{}, "")
+	flags.StringVar(&c.driver, "driver", "docker", "")
+	flags.StringVar(&c.podmanSocket, "podman-socket", "", "")
+	flags.StringVar(&c.metricsAddr, "metrics-addr", "", "")
+
+	defaultConfig := server.DefaultConfig()
+	flags.StringVar(&c.dataDir, "data-dir", defaultConfig.DataDir, "")
+	flags.DurationVar(&c.eventMaxAge, "event-max-age", defaultConfig.EventMaxAge, "")
+	flags.IntVar(&c.eventMaxPerDeployment, "event-max-per-deployment", defaultConfig.EventMaxPerDeployment, "")
+	flags.Float64Var(&c.diskWarnThreshold, "disk-warn-threshold", defaultConfig.DiskWarnThreshold, "")
+	flags.Float64Var(&c.diskStopThreshold, "disk-stop-threshold", defaultConfig.DiskStopThreshold, "")
 
 	if err := flags.Parse(args); err != nil {
 		c.UI.Error(err.Error())
@@ -53,15 +71,29 @@ func (c *ServerCommand) Run(args []string) int {
 		Level: hclog.LevelFromString(c.logLevel),
 	})
 
-	db, err := bolt.Open("vesta.db", 0600, nil)
-	if err != nil {
-		c.UI.Output(fmt.Sprintf("failed to open persistence layer: %v", err))
-		return 1
-	}
-
 	sCfg := server.DefaultConfig()
 	sCfg.Catalog = c.catalog
-	sCfg.PersistentDB = db
+	sCfg.DataDir = c.dataDir
+	sCfg.MetricsAddr = c.metricsAddr
+	sCfg.EventMaxAge = c.eventMaxAge
+	sCfg.EventMaxPerDeployment = c.eventMaxPerDeployment
+	sCfg.DiskWarnThreshold = c.diskWarnThreshold
+	sCfg.DiskStopThreshold = c.diskStopThreshold
+
+	switch c.driver {
+	case "docker":
+		// default driver
+	case "podman":
+		driver, err := backend.NewPodmanDriver(c.podmanSocket)
+		if err != nil {
+			c.UI.Output(fmt.Sprintf("failed to create podman driver: %v", err))
+			return 1
+		}
+		sCfg.Driver = driver
+	default:
+		c.UI.Output(fmt.Sprintf("driver '%s' not found", c.driver))
+		return 1
+	}
 
 	srv, err := server.NewServer(logger, sCfg)
 	if err != nil {
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mitchellh/cli"
+	flag "github.com/spf13/pflag"
+	"github.com/umbracle/vesta/internal/server"
+	"github.com/umbracle/vesta/internal/server/state2"
+)
+
+// ServerMigrateCommand is the command to migrate the schema of the state
+type ServerMigrateCommand struct {
+	UI cli.Ui
+
+	status  bool
+	dataDir string
+}
+
+// Help implements the cli.Command interface
+func (c *ServerMigrateCommand) Help() string {
+	return `Usage: vesta server migrate [options]
+
+  Apply the pending migrations to the schema of the state of the server`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *ServerMigrateCommand) Synopsis() string {
+	return "Migrate the schema of the state"
+}
+
+// Run implements the cli.Command interface
+func (c *ServerMigrateCommand) Run(args []string) int {
+	flags := flag.NewFlagSet("server migrate", flag.ContinueOnError)
+	flags.BoolVar(&c.status, "status", false, "")
+	flags.StringVar(&c.dataDir, "data-dir", server.DefaultConfig().DataDir, "")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	path := server.StatePath(c.dataDir)
+
+	if c.status {
+		// the state of a previous version is imported by the migration
+		legacy, err := server.LegacyStatePath(c.dataDir)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+		if legacy != "" {
+			path = legacy
+		}
+
+		migrations, err := state2.MigrationStatus(path)
+		if err != nil {
+			c.UI.Error(err.Error())
+			return 1
+		}
+		c.UI.Output(formatMigrations(migrations))
+		return 0
+	}
+
+	// the server cannot use the state while it is migrated
+	unlock, err := server.LockDataDir(c.dataDir)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	defer unlock()
+
+	imported, err := server.ImportLegacyState(c.dataDir)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if imported != "" {
+		c.UI.Warn(fmt.Sprintf("Imported the state from '%s', the file is not used anymore and it can be removed", imported))
+	}
+
+	applied, err := state2.Migrate(path)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if len(applied) == 0 {
+		c.UI.Output("No pending migrations")
+		return 0
+	}
+	c.UI.Output(formatMigrations(applied))
+	return 0
+}
+
+func formatMigrations(migrations []*state2.Migration) string {
+	rows := make([]string, len(migrations)+1)
+	rows[0] = "Version|Name|Status|Applied At"
+
+	for i, m := range migrations {
+		status, appliedAt := "Pending", "-"
+		if !m.Pending() {
+			status = "Applied"
+			appliedAt = m.AppliedAt.Format(time.RFC3339)
+		}
+		rows[i+1] = fmt.Sprintf("%d|%s|%s|%s", m.Version, m.Name, status, appliedAt)
+	}
+	return formatList(rows)
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/mitchellh/cli"
+)
+
+// VolumeCommand is the command to group the volume subcommands
+type VolumeCommand struct {
+	*Meta
+}
+
+// Help implements the cli.Command interface
+func (c *VolumeCommand) Help() string {
+	return `Usage: vesta volume
+
+  This command groups subcommands for interacting with the volumes of the deployments.`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *VolumeCommand) Synopsis() string {
+	return "Interact with volumes"
+}
+
+// Run implements the cli.Command interface
+func (c *VolumeCommand) Run(args []string) int {
+	return cli.RunResultHelp
+}
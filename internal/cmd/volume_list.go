@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+// VolumeListCommand is the command to list the snapshots of the volumes
+type VolumeListCommand struct {
+	*Meta
+}
+
+// Help implements the cli.Command interface
+func (c *VolumeListCommand) Help() string {
+	return `Usage: vesta volume ls [<deployment>]
+	
+  List the snapshots of the volumes`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *VolumeListCommand) Synopsis() string {
+	return "List the snapshots of the volumes"
+}
+
+// Run implements the cli.Command interface
+func (c *VolumeListCommand) Run(args []string) int {
+	flags := c.FlagSet("volume ls")
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) > 1 {
+		c.UI.Error("incorrect input, provide at most one argument")
+		return 1
+	}
+
+	req := &proto.VolumeListRequest{}
+	if len(args) == 1 {
+		req.Deployment = args[0]
+	}
+
+	client, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	resp, err := client.VolumeList(context.Background(), req)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(formatSnapshots(resp.Snapshots))
+	return 0
+}
+
+func formatSnapshots(snapshots []*proto.Snapshot) string {
+	if len(snapshots) == 0 {
+		return "No snapshots found"
+	}
+
+	rows := make([]string, len(snapshots)+1)
+	rows[0] = "ID|Deployment|Task|Volume|Size|Compressed|Created|Path"
+	for i, s := range snapshots {
+		rows[i+1] = fmt.Sprintf("%s|%s|%s|%s|%s|%v|%s|%s",
+			s.Id,
+			s.Deployment,
+			s.Task,
+			s.Volume,
+			units.HumanSize(float64(s.Size)),
+			s.Compressed,
+			time.Unix(s.CreatedAt, 0).Format(time.RFC3339),
+			s.Path,
+		)
+	}
+	return formatList(rows)
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+// VolumeRestoreCommand is the command to restore a snapshot into a volume
+type VolumeRestoreCommand struct {
+	*Meta
+
+	task   string
+	volume string
+}
+
+// Help implements the cli.Command interface
+func (c *VolumeRestoreCommand) Help() string {
+	return `Usage: vesta volume restore [options] <snapshot> [<deployment>]
+	
+  Restore a snapshot into a volume. It defaults to the volume of the snapshot`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *VolumeRestoreCommand) Synopsis() string {
+	return "Restore a snapshot into a volume"
+}
+
+// Run implements the cli.Command interface
+func (c *VolumeRestoreCommand) Run(args []string) int {
+	flags := c.FlagSet("volume restore")
+	flags.StringVar(&c.task, "task", "", "")
+	flags.StringVar(&c.volume, "volume", "", "")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 1 && len(args) != 2 {
+		c.UI.Error("incorrect input, provide the snapshot and optionally the deployment")
+		return 1
+	}
+
+	req := &proto.VolumeRestoreRequest{
+		Snapshot: args[0],
+		Task:     c.task,
+		Volume:   c.volume,
+	}
+	if len(args) == 2 {
+		req.Deployment = args[1]
+	}
+
+	client, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if _, err := client.VolumeRestore(context.Background(), req); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Snapshot %s restored", args[0]))
+	return 0
+}
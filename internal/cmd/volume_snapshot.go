@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/go-units"
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+// VolumeSnapshotCommand is the command to snapshot a volume
+type VolumeSnapshotCommand struct {
+	*Meta
+
+	path     string
+	compress bool
+}
+
+// Help implements the cli.Command interface
+func (c *VolumeSnapshotCommand) Help() string {
+	return `Usage: vesta volume snapshot [options] <deployment> <task> <volume>
+	
+  Archive a volume of a task. The tasks of the deployment are stopped during the snapshot`
+}
+
+// Synopsis implements the cli.Command interface
+func (c *VolumeSnapshotCommand) Synopsis() string {
+	return "Archive a volume of a task"
+}
+
+// Run implements the cli.Command interface
+func (c *VolumeSnapshotCommand) Run(args []string) int {
+	flags := c.FlagSet("volume snapshot")
+	flags.StringVar(&c.path, "path", "", "")
+	flags.BoolVar(&c.compress, "compress", false, "")
+
+	if err := flags.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	args = flags.Args()
+	if len(args) != 3 {
+		c.UI.Error("incorrect input, provide the deployment, the task and the volume")
+		return 1
+	}
+
+	req := &proto.VolumeSnapshotRequest{
+		Deployment: args[0],
+		Task:       args[1],
+		Volume:     args[2],
+		Compress:   c.compress,
+	}
+	if c.path != "" {
+		// the path is relative to the snapshots folder of the server
+		req.Path = c.path
+	}
+
+	client, err := c.Conn()
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	resp, err := client.VolumeSnapshot(context.Background(), req)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	c.UI.Output(fmt.Sprintf("Snapshot %s created (%s)", resp.Snapshot.Id, units.HumanSize(float64(resp.Snapshot.Size))))
+	c.UI.Output(resp.Snapshot.Path)
+	return 0
+}
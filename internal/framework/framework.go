@@ -5,6 +5,12 @@ import "github.com/umbracle/vesta/internal/server/proto"
 type Framework interface {
 	Config() map[string]*Field
 	Chains() []string
+	// Kind is the kind of client (i.e. execution, beacon) that
+	// the reference fields of other plugins can point to
+	Kind() string
+	// DiskSize is the expected disk size in bytes for each chain
+	DiskSize() map[string]uint64
+	Version() string
 	Generate(config *Config) map[string]*proto.Task
 }
 
@@ -12,4 +18,8 @@ type Config struct {
 	Metrics bool
 	Chain   string
 	Data    *FieldData
+
+	// JWTSecret is the secret that authenticates the engine API
+	// between an execution client and a consensus client
+	JWTSecret string
 }
@@ -24,6 +24,10 @@ type Field struct {
 
 	// AllowedValues is the list of allowed values for the field
 	AllowedValues []interface{}
+
+	// Kind is the kind of plugin (i.e. execution) of the deployments
+	// that a reference field can point to. Any kind if empty.
+	Kind string
 }
 
 func (s *Field) DefaultOrZero() interface{} {
@@ -40,11 +44,15 @@ const (
 	TypeString
 	TypeBool
 	TypeInt
+
+	// TypeReference is a reference to another deployment. It is
+	// resolved to the DNS name of the deployment.
+	TypeReference
 )
 
 func (t Type) Zero() interface{} {
 	switch t {
-	case TypeString:
+	case TypeString, TypeReference:
 		return ""
 	case TypeBool:
 		return false
@@ -63,6 +71,8 @@ func (t Type) String() string {
 		return "bool"
 	case TypeInt:
 		return "int"
+	case TypeReference:
+		return "reference"
 	default:
 		return "unknown type"
 	}
@@ -116,7 +126,7 @@ func (d *FieldData) GetOkErr(k string) (interface{}, bool, error) {
 	}
 
 	switch schema.Type {
-	case TypeString, TypeBool, TypeInt:
+	case TypeString, TypeBool, TypeInt, TypeReference:
 		return d.getPrimitive(k, schema)
 	default:
 		return nil, false,
@@ -131,7 +141,7 @@ func (d *FieldData) getPrimitive(k string, schema *Field) (interface{}, bool, er
 	}
 
 	switch t := schema.Type; t {
-	case TypeString:
+	case TypeString, TypeReference:
 		var result string
 		if err := mapstructure.WeakDecode(raw, &result); err != nil {
 			return nil, false, err
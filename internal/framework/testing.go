@@ -19,7 +19,9 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/hashicorp/go-getter"
+	"github.com/umbracle/vesta/internal/server/proto"
 	"github.com/umbracle/vesta/internal/uuid"
+	"github.com/umbracle/vesta/internal/verify"
 )
 
 type TestingFramework struct {
@@ -83,18 +85,21 @@ func (tf *TestingFramework) validateInput(input map[string]interface{}) error {
 		Raw:    input,
 	}
 
-	// fill in the `execution_node` field which is required in the
-	// beacon node clients. Later on, we will now that this field is a reference
-	// to another node and create a dummy entry here.
-	if _, ok := fields["execution_node"]; ok {
-		data.Raw["execution_node"] = "localhost"
+	// the references to other deployments (i.e. the execution node of the
+	// beacon node clients) point to a dummy host since the nodes do not
+	// need to be reachable to validate the flags.
+	for name, field := range fields {
+		if field.Type == TypeReference {
+			data.Raw[name] = "localhost"
+		}
 	}
 
 	cfg := &Config{
 		// since we do not run validate, it does not need any input data
-		Chain:   input["chain"].(string),
-		Metrics: input["metrics"].(bool),
-		Data:    data,
+		Chain:     input["chain"].(string),
+		Metrics:   input["metrics"].(bool),
+		Data:      data,
+		JWTSecret: testJWTSecret,
 	}
 
 	delete(input, "chain")
@@ -143,13 +148,10 @@ func (tf *TestingFramework) validateInput(input map[string]interface{}) error {
 			if _, ok := tf.Artifacts[artifact.Source]; !ok {
 				dstFile := filepath.Join(artifactsDir, uuid.Short())
 
-				client := &getter.Client{
-					Ctx:  context.Background(),
-					Src:  artifact.Source,
-					Dst:  dstFile,
-					Mode: getter.ClientModeFile,
+				if err := downloadFile(artifact.Source, dstFile); err != nil {
+					return err
 				}
-				if err := client.Get(); err != nil {
+				if err := verifyArtifact(artifact, dstFile); err != nil {
 					return err
 				}
 
@@ -257,6 +259,9 @@ func (tf *TestingFramework) validateInput(input map[string]interface{}) error {
 	return nil
 }
 
+// testJWTSecret is the JWT secret of the nodes run by the tests
+var testJWTSecret = "04592280e1778419b7aa954d43871cb2cfb2ebda754fb735e8adeb293a88f9bf"
+
 // hard code field that do not work for the automatic tests
 var skipFields = map[string]struct{}{
 	// prysm requires the network to be reachable to access the checkpoint
@@ -335,6 +340,40 @@ func generateMinimumCombinations(vals map[string][]interface{}) []map[string]int
 	return combinations
 }
 
+func downloadFile(src, dst string) error {
+	client := &getter.Client{
+		Ctx:  context.Background(),
+		Src:  src,
+		Dst:  dst,
+		Mode: getter.ClientModeFile,
+	}
+	return client.Get()
+}
+
+// verifyArtifact checks the sha256 digest and the signature of the
+// artifact like the backend does before the artifact is used
+func verifyArtifact(artifact *proto.Task_Artifact, path string) error {
+	if artifact.Sha256 != "" {
+		if err := verify.SHA256(artifact.Source, path, artifact.Sha256); err != nil {
+			return err
+		}
+	}
+	if artifact.Signature != "" {
+		sigFile := path + ".minisig"
+		if err := downloadFile(artifact.Signature, sigFile); err != nil {
+			return err
+		}
+		signature, err := os.ReadFile(sigFile)
+		if err != nil {
+			return err
+		}
+		if err := verify.Signature(artifact.Source, path, artifact.PublicKey, signature); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func copyFile(from, to string) error {
 	// Open the source file
 	sourceFile, err := os.Open(from)
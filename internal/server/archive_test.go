@@ -0,0 +1,99 @@
+package server
+
+import (
+	"archive/tar"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "chain", "db"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "chain", "db", "000001.log"), []byte("log"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nodekey"), []byte("key"), 0600))
+	require.NoError(t, os.Symlink("chain/db", filepath.Join(dir, "db")))
+
+	for _, compress := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "snapshot.tar")
+
+		size, err := writeArchive(dir, path, compress)
+		require.NoError(t, err)
+		require.NotZero(t, size)
+
+		// the content of the target directory is replaced
+		target := filepath.Join(t.TempDir(), "volume")
+		require.NoError(t, os.MkdirAll(target, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(target, "other"), []byte("other"), 0644))
+
+		require.NoError(t, extractArchive(context.Background(), path, target, compress))
+
+		data, err := os.ReadFile(filepath.Join(target, "chain", "db", "000001.log"))
+		require.NoError(t, err)
+		require.Equal(t, "log", string(data))
+
+		info, err := os.Stat(filepath.Join(target, "nodekey"))
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		link, err := os.Readlink(filepath.Join(target, "db"))
+		require.NoError(t, err)
+		require.Equal(t, "chain/db", link)
+
+		_, err = os.Stat(filepath.Join(target, "other"))
+		require.True(t, os.IsNotExist(err))
+	}
+}
+
+func TestArchive_InvalidPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.tar")
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	tw := tar.NewWriter(f)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../evil", Mode: 0644, Size: 1, Typeflag: tar.TypeReg}))
+	_, err = tw.Write([]byte("a"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, f.Close())
+
+	// the target directory is not modified
+	target := filepath.Join(t.TempDir(), "volume")
+	require.NoError(t, os.MkdirAll(target, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "file"), []byte("file"), 0644))
+
+	require.Error(t, extractArchive(context.Background(), path, target, false))
+
+	_, err = os.Stat(filepath.Join(target, "file"))
+	require.NoError(t, err)
+}
+
+func TestArchive_SymlinkEscape(t *testing.T) {
+	outside := t.TempDir()
+	path := filepath.Join(t.TempDir(), "snapshot.tar")
+
+	f, err := os.Create(path)
+	require.NoError(t, err)
+
+	// the second entry writes through the symlink of the first one
+	tw := tar.NewWriter(f)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "link", Linkname: outside, Typeflag: tar.TypeSymlink}))
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "link/evil", Mode: 0644, Size: 1, Typeflag: tar.TypeReg}))
+	_, err = tw.Write([]byte("a"))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, f.Close())
+
+	target := filepath.Join(t.TempDir(), "volume")
+	require.NoError(t, os.MkdirAll(target, 0755))
+
+	require.Error(t, extractArchive(context.Background(), path, target, false))
+
+	_, err = os.Stat(filepath.Join(outside, "evil"))
+	require.True(t, os.IsNotExist(err))
+}
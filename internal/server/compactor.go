@@ -0,0 +1,96 @@
+package server
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/umbracle/vesta/internal/server/state2"
+)
+
+type compactorConfig struct {
+	// Interval is the period between compactions
+	Interval time.Duration
+
+	// MaxAge is the maximum age of the events. Zero keeps
+	// the events forever.
+	MaxAge time.Duration
+
+	// MaxEvents is the maximum number of events kept for each
+	// deployment. Zero does not limit the number of events.
+	MaxEvents int
+}
+
+func defaultCompactorConfig() *compactorConfig {
+	return &compactorConfig{
+		Interval:  10 * time.Minute,
+		MaxAge:    7 * 24 * time.Hour,
+		MaxEvents: 1000,
+	}
+}
+
+// compactor removes periodically the events that are out of the
+// retention policy.
+type compactor struct {
+	logger  hclog.Logger
+	config  *compactorConfig
+	state   *state2.State
+	removed prometheus.Counter
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+func newCompactor(logger hclog.Logger, config *compactorConfig, state *state2.State) *compactor {
+	return &compactor{
+		logger: logger.Named("compactor"),
+		config: config,
+		state:  state,
+		removed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "vesta",
+			Name:      "events_compacted_total",
+			Help:      "Number of events removed by the retention policy",
+		}),
+		closeCh: make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+}
+
+func (c *compactor) start() {
+	go c.run()
+}
+
+// stop stops the compactor and waits for the current compaction to finish
+func (c *compactor) stop() {
+	close(c.closeCh)
+	<-c.doneCh
+}
+
+func (c *compactor) run() {
+	defer close(c.doneCh)
+
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		c.compact()
+
+		select {
+		case <-ticker.C:
+		case <-c.closeCh:
+			return
+		}
+	}
+}
+
+func (c *compactor) compact() {
+	removed, err := c.state.CompactEvents(c.config.MaxAge, c.config.MaxEvents)
+	if err != nil {
+		c.logger.Error("failed to compact events", "err", err)
+		return
+	}
+	if removed != 0 {
+		c.logger.Debug("compacted events", "removed", removed)
+	}
+	c.removed.Add(float64(removed))
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/server/proto"
+	"github.com/umbracle/vesta/internal/server/state2"
+)
+
+func TestCompactor(t *testing.T) {
+	state, err := state2.NewState(filepath.Join(t.TempDir(), "state.db"))
+	require.NoError(t, err)
+	defer state.Close()
+
+	require.NoError(t, state.CreateDeployment(&proto.Deployment2{Id: "a", Spec: []byte("spec")}, nil))
+	for i := 0; i < 5; i++ {
+		event := &proto.Event2{Deployment: "a", Type: "start"}
+		describeEvent(event)
+		require.NoError(t, state.CreateEvent(event))
+	}
+
+	config := defaultCompactorConfig()
+	config.MaxEvents = 2
+
+	c := newCompactor(hclog.NewNullLogger(), config, state)
+	c.compact()
+
+	events, err := state.ListEvents("a", 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, float64(3), testutil.ToFloat64(c.removed))
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// The data directory holds all the state of the server:
+//
+//	<data-dir>/
+//	├── vesta.lock   lock held by the server that uses the directory
+//	├── state.db     database with the deployments, tasks and events
+//	├── data/        data files rendered for the tasks (data/<deployment>/<task>/<path>)
+//	├── artifacts/   artifacts downloaded for the tasks
+//	├── volumes/     volumes of the tasks
+//	└── snapshots/   snapshots of the volumes
+const (
+	dataDirLock      = "vesta.lock"
+	dataDirState     = "state.db"
+	dataDirData      = "data"
+	dataDirArtifacts = "artifacts"
+	dataDirVolumes   = "volumes"
+	dataDirSnapshots = "snapshots"
+)
+
+// legacyStatePath is the database of the state used by the versions
+// before the data directory, relative to the working directory
+const legacyStatePath = "example.db"
+
+// errLocked is returned by lockFile if another process holds the lock
+var errLocked = errors.New("locked")
+
+// StatePath returns the path of the database of the state in the data directory
+func StatePath(dataDir string) string {
+	return filepath.Join(dataDir, dataDirState)
+}
+
+// LegacyStatePath returns the absolute path of the database used before the data
+// directory if it exists and the data directory does not have a state yet.
+func LegacyStatePath(dataDir string) (string, error) {
+	if _, err := os.Stat(StatePath(dataDir)); err == nil {
+		return "", nil
+	} else if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	path, err := filepath.Abs(legacyStatePath)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return path, nil
+}
+
+// ImportLegacyState copies the database used before the data directory into the
+// data directory if it does not have a state yet. It returns the path of the
+// imported database or an empty string if there is nothing to import.
+func ImportLegacyState(dataDir string) (string, error) {
+	src, err := LegacyStatePath(dataDir)
+	if err != nil || src == "" {
+		return "", err
+	}
+
+	// the database is copied under a temporary name so that a partial
+	// copy is not used as the state if the import fails
+	dst := StatePath(dataDir)
+	tmp := dst + ".import"
+	if err := os.RemoveAll(tmp); err != nil {
+		return "", err
+	}
+	if err := copyFile(src, tmp, 0600); err != nil {
+		return "", fmt.Errorf("failed to import the state from '%s': %v", src, err)
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		return "", fmt.Errorf("failed to import the state from '%s': %v", src, err)
+	}
+	return src, nil
+}
+
+// LockDataDir creates the data directory and takes its lock. It fails if
+// another process holds the lock. The lock is released with the returned function.
+func LockDataDir(dataDir string) (func() error, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create data dir '%s': %v", dataDir, err)
+	}
+
+	f, err := os.OpenFile(filepath.Join(dataDir, dataDirLock), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		if err == errLocked {
+			return nil, fmt.Errorf("data dir '%s' is in use by another process", dataDir)
+		}
+		return nil, fmt.Errorf("failed to lock data dir '%s': %v", dataDir, err)
+	}
+
+	// the pid is only informative, the lock is held on the file
+	if err := f.Truncate(0); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	unlock := func() error {
+		// closing the file releases the lock
+		return f.Close()
+	}
+	return unlock, nil
+}
+
+// setupDataDir creates the layout of the data directory
+func setupDataDir(dataDir string) error {
+	for _, dir := range []string{dataDirData, dataDirArtifacts, dataDirVolumes, dataDirSnapshots} {
+		if err := os.MkdirAll(filepath.Join(dataDir, dir), 0755); err != nil {
+			return fmt.Errorf("failed to create data dir '%s': %v", dir, err)
+		}
+	}
+	return nil
+}
+
+// defaultDataDir returns the data directory under the home of the user
+func defaultDataDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".vesta"
+	}
+	return filepath.Join(home, ".vesta")
+}
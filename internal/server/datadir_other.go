@@ -0,0 +1,13 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package server
+
+import (
+	"os"
+)
+
+// lockFile is a no-op on the platforms without flock, the data
+// directory is not protected against concurrent servers.
+func lockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/server/proto"
+	"github.com/umbracle/vesta/internal/server/state2"
+)
+
+func TestLockDataDir(t *testing.T) {
+	dataDir := filepath.Join(t.TempDir(), "data")
+
+	unlock, err := LockDataDir(dataDir)
+	require.NoError(t, err)
+
+	// the directory is in use
+	_, err = LockDataDir(dataDir)
+	require.Error(t, err)
+
+	require.NoError(t, unlock())
+
+	unlock, err = LockDataDir(dataDir)
+	require.NoError(t, err)
+	require.NoError(t, unlock())
+}
+
+func TestSetupDataDir(t *testing.T) {
+	dataDir := t.TempDir()
+	require.NoError(t, setupDataDir(dataDir))
+
+	for _, dir := range []string{dataDirData, dataDirArtifacts, dataDirVolumes, dataDirSnapshots} {
+		info, err := os.Stat(filepath.Join(dataDir, dir))
+		require.NoError(t, err)
+		require.True(t, info.IsDir())
+	}
+}
+
+func TestImportLegacyState(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	defer os.Chdir(wd)
+
+	// the state of a previous version in the working directory
+	require.NoError(t, os.Chdir(t.TempDir()))
+
+	legacy, err := state2.NewState(legacyStatePath)
+	require.NoError(t, err)
+	require.NoError(t, legacy.CreateDeployment(&proto.Deployment2{Id: "a", Spec: []byte("spec")}, nil))
+	require.NoError(t, legacy.Close())
+
+	dataDir := t.TempDir()
+
+	imported, err := ImportLegacyState(dataDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, imported)
+
+	state, err := state2.NewState(StatePath(dataDir))
+	require.NoError(t, err)
+	defer state.Close()
+
+	deployments, err := state.ListDeployments()
+	require.NoError(t, err)
+	require.Len(t, deployments, 1)
+
+	// the state is only imported once
+	imported, err = ImportLegacyState(dataDir)
+	require.NoError(t, err)
+	require.Empty(t, imported)
+}
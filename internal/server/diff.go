@@ -0,0 +1,133 @@
+package server
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+// diffTasks computes the changes required to move from the old tasks
+// of a deployment to the new ones. Only the fields that require a new
+// container (image, args, env, labels, security options, data, volumes,
+// artifacts and batch) are compared.
+func diffTasks(old, new map[string]*proto.Task) []*proto.TaskDiff {
+	diffs := []*proto.TaskDiff{}
+
+	for name, newTask := range new {
+		oldTask, ok := old[name]
+		if !ok {
+			diffs = append(diffs, &proto.TaskDiff{
+				Name: name,
+				Type: proto.TaskDiff_Create,
+			})
+			continue
+		}
+
+		diff := &proto.TaskDiff{
+			Name:   name,
+			Type:   proto.TaskDiff_Unchanged,
+			Fields: diffTask(oldTask, newTask),
+		}
+		if len(diff.Fields) != 0 {
+			diff.Type = proto.TaskDiff_Replace
+		}
+		diffs = append(diffs, diff)
+	}
+
+	for name := range old {
+		if _, ok := new[name]; !ok {
+			diffs = append(diffs, &proto.TaskDiff{
+				Name: name,
+				Type: proto.TaskDiff_Destroy,
+			})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Name < diffs[j].Name
+	})
+	return diffs
+}
+
+// hasChanges returns whether any of the diffs creates, replaces or destroys a task
+func hasChanges(diffs []*proto.TaskDiff) bool {
+	for _, diff := range diffs {
+		if diff.Type != proto.TaskDiff_Unchanged {
+			return true
+		}
+	}
+	return false
+}
+
+func diffTask(old, new *proto.Task) []string {
+	fields := []string{}
+
+	if old.Image != new.Image || old.Tag != new.Tag {
+		fields = append(fields, "image")
+	}
+	if !equalSlice(old.Args, new.Args) {
+		fields = append(fields, "args")
+	}
+	if !equalMap(old.Env, new.Env) {
+		fields = append(fields, "env")
+	}
+	if !equalMap(old.Labels, new.Labels) {
+		fields = append(fields, "labels")
+	}
+	if !equalSlice(old.SecurityOpt, new.SecurityOpt) {
+		fields = append(fields, "securityOpt")
+	}
+	if !equalMap(old.Data, new.Data) {
+		fields = append(fields, "data")
+	}
+
+	oldVolumes := map[string]string{}
+	for name, vol := range old.Volumes {
+		oldVolumes[name] = vol.Path
+	}
+	newVolumes := map[string]string{}
+	for name, vol := range new.Volumes {
+		newVolumes[name] = vol.Path
+	}
+	if !equalMap(oldVolumes, newVolumes) {
+		fields = append(fields, "volumes")
+	}
+
+	oldArtifacts := map[string]string{}
+	for _, artifact := range old.Artifacts {
+		oldArtifacts[artifact.Destination] = artifactKey(artifact)
+	}
+	newArtifacts := map[string]string{}
+	for _, artifact := range new.Artifacts {
+		newArtifacts[artifact.Destination] = artifactKey(artifact)
+	}
+	if !equalMap(oldArtifacts, newArtifacts) {
+		fields = append(fields, "artifacts")
+	}
+	if old.Batch != new.Batch {
+		fields = append(fields, "batch")
+	}
+
+	return fields
+}
+
+// artifactKey identifies the content of an artifact
+func artifactKey(artifact *proto.Task_Artifact) string {
+	return strings.Join([]string{artifact.Source, artifact.Sha256, artifact.Signature, artifact.PublicKey}, "|")
+}
+
+func equalSlice(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func equalMap(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
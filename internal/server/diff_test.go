@@ -0,0 +1,52 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+func TestDiffTasks(t *testing.T) {
+	old := map[string]*proto.Task{
+		"a": {Image: "a", Tag: "1", Args: []string{"--a"}},
+		"b": {Image: "b", Tag: "1", Env: map[string]string{"A": "B"}},
+		"c": {Image: "c", Tag: "1"},
+		"d": {Image: "d", Tag: "1", Volumes: map[string]*proto.Task_Volume{"data": {Path: "/data"}}},
+		"f": {Image: "f", Tag: "1", Artifacts: []*proto.Task_Artifact{{Source: "a", Destination: "/data/a"}}},
+		"g": {Image: "g", Tag: "1", Labels: map[string]string{"a": "b"}, SecurityOpt: []string{"a"}},
+	}
+	new := map[string]*proto.Task{
+		// change in tag and args
+		"a": {Image: "a", Tag: "2", Args: []string{"--b"}},
+		// empty and nil maps are equal
+		"b": {Image: "b", Tag: "1", Env: map[string]string{"A": "B"}, Data: map[string]string{}},
+		"d": {Image: "d", Tag: "1", Volumes: map[string]*proto.Task_Volume{"data": {Path: "/data2"}}},
+		"e": {Image: "e", Tag: "1"},
+		"f": {Image: "f", Tag: "1", Artifacts: []*proto.Task_Artifact{{Source: "a", Destination: "/data/a", Sha256: "a"}}},
+		// change in labels, security options and batch
+		"g": {Image: "g", Tag: "1", Labels: map[string]string{"a": "c"}, SecurityOpt: []string{"b"}, Batch: true},
+	}
+
+	diffs := diffTasks(old, new)
+	require.Len(t, diffs, 7)
+
+	expected := []struct {
+		name   string
+		typ    proto.TaskDiff_Type
+		fields []string
+	}{
+		{"a", proto.TaskDiff_Replace, []string{"image", "args"}},
+		{"b", proto.TaskDiff_Unchanged, []string{}},
+		{"c", proto.TaskDiff_Destroy, nil},
+		{"d", proto.TaskDiff_Replace, []string{"volumes"}},
+		{"e", proto.TaskDiff_Create, nil},
+		{"f", proto.TaskDiff_Replace, []string{"artifacts"}},
+		{"g", proto.TaskDiff_Replace, []string{"labels", "securityOpt", "batch"}},
+	}
+	for i, e := range expected {
+		require.Equal(t, e.name, diffs[i].Name)
+		require.Equal(t, e.typ, diffs[i].Type)
+		require.Equal(t, e.fields, diffs[i].Fields)
+	}
+}
@@ -0,0 +1,330 @@
+package server
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/umbracle/vesta/internal/server/proto"
+	"github.com/umbracle/vesta/internal/uuid"
+)
+
+type diskMonitorConfig struct {
+	// Interval is the period between measurements
+	Interval time.Duration
+
+	// WarnThreshold is the percentage of used space of the host
+	// filesystem that emits warning events. Zero disables it.
+	WarnThreshold float64
+
+	// StopThreshold is the percentage of used space of the host
+	// filesystem that stops the deployments. Zero disables it.
+	StopThreshold float64
+}
+
+func defaultDiskMonitorConfig() *diskMonitorConfig {
+	return &diskMonitorConfig{
+		Interval:      time.Minute,
+		WarnThreshold: 90,
+	}
+}
+
+// diskLevel is the pressure of the host filesystem
+type diskLevel int
+
+const (
+	diskLevelNormal diskLevel = iota
+	diskLevelWarn
+	diskLevelStop
+)
+
+// diskStopReason is the reason of the deployments stopped by the disk monitor
+const diskStopReason = "disk"
+
+// hostDiskUsage is the usage of the host filesystem in bytes
+type hostDiskUsage struct {
+	Used  uint64
+	Total uint64
+}
+
+func (h *hostDiskUsage) percent() float64 {
+	if h.Total == 0 {
+		return 0
+	}
+	return float64(h.Used) / float64(h.Total) * 100
+}
+
+// diskMonitor measures periodically the size of the volumes of each
+// deployment and the usage of the host filesystem. If the host runs
+// out of space, it emits warning events and stops the deployments.
+type diskMonitor struct {
+	logger     hclog.Logger
+	config     *diskMonitorConfig
+	reconciler *reconciler
+
+	// volumesDir is the directory with the volumes of the deployments
+	volumesDir string
+
+	// statfs returns the usage of the filesystem of a path
+	statfs func(path string) (*hostDiskUsage, error)
+
+	lock  sync.Mutex
+	usage map[string]uint64
+	host  hostDiskUsage
+	level diskLevel
+
+	usageDesc     *prometheus.Desc
+	hostUsedDesc  *prometheus.Desc
+	hostTotalDesc *prometheus.Desc
+
+	closeCh chan struct{}
+	doneCh  chan struct{}
+}
+
+func newDiskMonitor(logger hclog.Logger, config *diskMonitorConfig, volumesDir string, reconciler *reconciler) *diskMonitor {
+	return &diskMonitor{
+		logger:     logger.Named("disk"),
+		config:     config,
+		reconciler: reconciler,
+		volumesDir: volumesDir,
+		statfs:     statfsUsage,
+		usage:      map[string]uint64{},
+		usageDesc: prometheus.NewDesc(
+			"vesta_deployment_disk_usage_bytes",
+			"Size of the volumes of each deployment",
+			[]string{"deployment"}, nil,
+		),
+		hostUsedDesc: prometheus.NewDesc(
+			"vesta_host_disk_used_bytes",
+			"Used space of the host filesystem with the volumes",
+			nil, nil,
+		),
+		hostTotalDesc: prometheus.NewDesc(
+			"vesta_host_disk_total_bytes",
+			"Total space of the host filesystem with the volumes",
+			nil, nil,
+		),
+		closeCh: make(chan struct{}),
+		doneCh:  make(chan struct{}),
+	}
+}
+
+func (d *diskMonitor) start() {
+	go d.run()
+}
+
+// stop stops the monitor and waits for the current measurement to finish
+func (d *diskMonitor) stop() {
+	close(d.closeCh)
+	<-d.doneCh
+}
+
+func (d *diskMonitor) run() {
+	defer close(d.doneCh)
+
+	ticker := time.NewTicker(d.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		d.check()
+
+		select {
+		case <-ticker.C:
+		case <-d.closeCh:
+			return
+		}
+	}
+}
+
+// deploymentUsage returns the size of the volumes of the deployment
+// in the last measurement
+func (d *diskMonitor) deploymentUsage(deployment string) uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.usage[deployment]
+}
+
+func (d *diskMonitor) check() {
+	deployments, err := d.reconciler.state.ListDeployments()
+	if err != nil {
+		d.logger.Error("failed to list deployments", "err", err)
+		return
+	}
+
+	usage := map[string]uint64{}
+	for _, dep := range deployments {
+		if dep.Status == proto.Deployment2_Destroyed {
+			continue
+		}
+		size, err := dirSize(filepath.Join(d.volumesDir, dep.Id))
+		if err != nil {
+			d.logger.Error("failed to compute disk usage", "deployment", dep.Id, "err", err)
+			continue
+		}
+		usage[dep.Id] = size
+	}
+
+	host, err := d.statfs(d.volumesDir)
+	if err != nil {
+		d.logger.Error("failed to compute host disk usage", "err", err)
+		return
+	}
+
+	d.lock.Lock()
+	d.usage = usage
+	d.host = *host
+	d.lock.Unlock()
+
+	d.checkThresholds(host)
+}
+
+// checkThresholds compares the usage of the host filesystem with the
+// thresholds. The actions are computed from the status of the deployments
+// stored in the state on every pass, a deployment that failed to stop is
+// stopped again on the next pass and the deployments stopped by the monitor
+// are resumed once the usage is back under the warning threshold, even after
+// a restart. The deployments stopped for other reasons are not resumed.
+func (d *diskMonitor) checkThresholds(host *hostDiskUsage) {
+	percent := host.percent()
+
+	level := diskLevelNormal
+	if d.config.StopThreshold != 0 && percent >= d.config.StopThreshold {
+		level = diskLevelStop
+	} else if d.config.WarnThreshold != 0 && percent >= d.config.WarnThreshold {
+		level = diskLevelWarn
+	}
+
+	prev := d.level
+	d.level = level
+
+	d.reconciler.lock.Lock()
+	defer d.reconciler.lock.Unlock()
+
+	deployments, err := d.reconciler.state.ListDeployments()
+	if err != nil {
+		d.logger.Error("failed to list deployments", "err", err)
+		return
+	}
+
+	attrs := map[string]string{
+		"usage": fmt.Sprintf("%.0f", percent),
+	}
+	switch level {
+	case diskLevelWarn:
+		// the warning is only emitted when the usage goes over the
+		// threshold, the stopped deployments are not resumed yet
+		if prev != diskLevelNormal {
+			return
+		}
+		d.logger.Warn("host is running out of disk", "usage", percent)
+
+		for _, dep := range deployments {
+			if dep.Status == proto.Deployment2_Running {
+				d.emit(dep.Id, proto.DeploymentDiskPressure, attrs)
+			}
+		}
+
+	case diskLevelStop:
+		if prev != diskLevelStop {
+			d.logger.Error("host is out of disk, stopping the deployments", "usage", percent)
+		}
+
+		for _, dep := range deployments {
+			if dep.Status != proto.Deployment2_Running {
+				continue
+			}
+			if err := d.reconciler.swarm.Destroy(dep.Id, false); err != nil {
+				d.logger.Error("failed to stop deployment", "id", dep.Id, "err", err)
+				continue
+			}
+			if err := d.reconciler.state.StopDeployment(dep.Id, diskStopReason); err != nil {
+				d.logger.Error("failed to update deployment", "id", dep.Id, "err", err)
+				continue
+			}
+			d.reconciler.forgetLocked(dep.Id)
+			d.emit(dep.Id, proto.DeploymentStopped, attrs)
+		}
+
+	case diskLevelNormal:
+		logged, resumed := false, false
+		for _, dep := range deployments {
+			if dep.Status != proto.Deployment2_Stopped || dep.StopReason != diskStopReason {
+				continue
+			}
+			if !logged {
+				d.logger.Info("host disk usage is back to normal, resuming the deployments", "usage", percent)
+				logged = true
+			}
+			if err := d.reconciler.state.UpdateDeploymentStatus(dep.Id, proto.Deployment2_Running); err != nil {
+				d.logger.Error("failed to update deployment", "id", dep.Id, "err", err)
+				continue
+			}
+			d.emit(dep.Id, proto.DeploymentResumed, attrs)
+			resumed = true
+		}
+		if resumed {
+			// the tasks of the resumed deployments are created again
+			d.reconciler.notify()
+		}
+	}
+}
+
+func (d *diskMonitor) emit(deployment, typ string, attrs map[string]string) {
+	d.reconciler.updater.UpdateEvent(&proto.Event2{
+		Id:         uuid.Generate(),
+		Deployment: deployment,
+		Type:       typ,
+		Attributes: attrs,
+	})
+}
+
+func (d *diskMonitor) Describe(ch chan<- *prometheus.Desc) {
+	ch <- d.usageDesc
+	ch <- d.hostUsedDesc
+	ch <- d.hostTotalDesc
+}
+
+func (d *diskMonitor) Collect(ch chan<- prometheus.Metric) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	for deployment, size := range d.usage {
+		ch <- prometheus.MustNewConstMetric(d.usageDesc, prometheus.GaugeValue, float64(size), deployment)
+	}
+	ch <- prometheus.MustNewConstMetric(d.hostUsedDesc, prometheus.GaugeValue, float64(d.host.Used))
+	ch <- prometheus.MustNewConstMetric(d.hostTotalDesc, prometheus.GaugeValue, float64(d.host.Total))
+}
+
+// dirSize returns the size of the files in the directory
+func dirSize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				// the directory does not exist yet or the
+				// file was removed during the walk
+				return nil
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
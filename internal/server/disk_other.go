@@ -0,0 +1,9 @@
+//go:build !(darwin || freebsd || linux)
+
+package server
+
+import "fmt"
+
+func statfsUsage(path string) (*hostDiskUsage, error) {
+	return nil, fmt.Errorf("disk usage is not supported on this platform")
+}
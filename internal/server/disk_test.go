@@ -0,0 +1,135 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/mock"
+	"github.com/umbracle/vesta/internal/server/proto"
+	"github.com/umbracle/vesta/internal/testutil"
+)
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "file"), make([]byte, 10), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a", "b", "file"), make([]byte, 5), 0644))
+
+	size, err := dirSize(dir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(15), size)
+
+	// the directory does not exist
+	size, err = dirSize(filepath.Join(dir, "c"))
+	require.NoError(t, err)
+	require.Zero(t, size)
+}
+
+func TestDiskMonitor(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, driver := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	// a deployment stopped by something else than the monitor
+	other, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x2}})
+	require.NoError(t, err)
+	require.NoError(t, srv.state2.StopDeployment(other, "user"))
+
+	volumesDir := srv.disk.volumesDir
+	require.NoError(t, os.MkdirAll(filepath.Join(volumesDir, id, "task", "data"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(volumesDir, id, "task", "data", "file"), make([]byte, 10), 0644))
+
+	config := &diskMonitorConfig{
+		WarnThreshold: 90,
+		StopThreshold: 95,
+	}
+	d := newDiskMonitor(srv.logger, config, volumesDir, srv.reconciler)
+
+	var used uint64
+	d.statfs = func(path string) (*hostDiskUsage, error) {
+		return &hostDiskUsage{Used: used, Total: 100}, nil
+	}
+
+	countEvents := func(typ string) int {
+		events, err := srv.state2.GetEventsByDeployment(id)
+		require.NoError(t, err)
+
+		num := 0
+		for _, event := range events {
+			if event.Type == typ {
+				num++
+			}
+		}
+		return num
+	}
+	status := func() proto.Deployment2_Status {
+		dep, err := srv.state2.GetDeploymentById(id)
+		require.NoError(t, err)
+		return dep.Status
+	}
+	otherStatus := func() proto.Deployment2_Status {
+		dep, err := srv.state2.GetDeploymentById(other)
+		require.NoError(t, err)
+		return dep.Status
+	}
+
+	used = 50
+	d.check()
+	require.Equal(t, uint64(10), d.deploymentUsage(id))
+	require.Zero(t, countEvents(proto.DeploymentDiskPressure))
+
+	// warning threshold, the event is only emitted once
+	used = 92
+	d.check()
+	d.check()
+	require.Equal(t, 1, countEvents(proto.DeploymentDiskPressure))
+
+	// stop threshold, the tasks are removed
+	used = 97
+	d.check()
+	require.Equal(t, proto.Deployment2_Stopped, status())
+	require.Equal(t, 1, countEvents(proto.DeploymentStopped))
+	require.Nil(t, driver.Spec("task-"+id))
+
+	// and they are not created again by the reconciler
+	srv.reconciler.reconcileAll()
+	require.Nil(t, driver.Spec("task-"+id))
+
+	// the deployment is still stopped under the stop threshold
+	used = 92
+	d.check()
+	require.Equal(t, proto.Deployment2_Stopped, status())
+
+	// the deployment is resumed under the warning threshold, also
+	// by a new monitor after a restart of the server
+	d = newDiskMonitor(srv.logger, config, volumesDir, srv.reconciler)
+	d.statfs = func(path string) (*hostDiskUsage, error) {
+		return &hostDiskUsage{Used: used, Total: 100}, nil
+	}
+
+	used = 50
+	d.check()
+	d.check()
+	require.Equal(t, proto.Deployment2_Running, status())
+	require.Equal(t, 1, countEvents(proto.DeploymentResumed))
+
+	// only the deployments stopped by the monitor are resumed
+	require.Equal(t, proto.Deployment2_Stopped, otherStatus())
+
+	testutil.WaitForResult(func() (bool, error) {
+		if driver.Spec("task-"+id) == nil {
+			return false, fmt.Errorf("task not created")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
@@ -0,0 +1,17 @@
+//go:build darwin || freebsd || linux
+
+package server
+
+import "golang.org/x/sys/unix"
+
+// statfsUsage returns the usage of the filesystem of the path
+func statfsUsage(path string) (*hostDiskUsage, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return nil, err
+	}
+	total := uint64(stat.Blocks) * uint64(stat.Bsize)
+	free := uint64(stat.Bavail) * uint64(stat.Bsize)
+
+	return &hostDiskUsage{Used: total - free, Total: total}, nil
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+// describeEvent sets the timestamp, the severity and the message
+// of an event unless they are already set.
+func describeEvent(event *proto.Event2) {
+	if event.Timestamp == 0 {
+		event.Timestamp = time.Now().UnixNano()
+	}
+	if event.Message != "" {
+		return
+	}
+
+	severity := proto.Event2_Info
+	var message string
+
+	switch event.Type {
+	case "create":
+		message = "Task created"
+	case "start":
+		message = "Task started"
+	case "stop":
+		message = "Task stopped"
+	case "destroy":
+		message = "Task removed"
+	case "die":
+		if code := event.Attributes["exitCode"]; code != "" && code != "0" {
+			severity = proto.Event2_Error
+			message = fmt.Sprintf("Task exited with code %s", code)
+		} else {
+			message = "Task exited successfully"
+		}
+	case "kill":
+		severity = proto.Event2_Warning
+		message = "Task killed"
+		if signal := event.Attributes["signal"]; signal != "" {
+			message += fmt.Sprintf(" with signal %s", signal)
+		}
+	case "oom":
+		severity = proto.Event2_Error
+		message = "Task ran out of memory"
+	case proto.TaskRestarting:
+		severity = proto.Event2_Warning
+		message = "Restarting the task after a failure"
+	case proto.TaskVerificationFailed:
+		severity = proto.Event2_Error
+		message = fmt.Sprintf("Failed to verify artifact '%s': %s", event.Attributes["source"], event.Attributes["reason"])
+	case proto.DeploymentDestroyed:
+		message = "Deployment destroyed"
+	case proto.DeploymentDiskPressure:
+		severity = proto.Event2_Warning
+		message = fmt.Sprintf("Host filesystem is %s%% full", event.Attributes["usage"])
+	case proto.DeploymentStopped:
+		severity = proto.Event2_Error
+		message = fmt.Sprintf("Deployment stopped, the host filesystem is %s%% full", event.Attributes["usage"])
+	case proto.DeploymentResumed:
+		message = "Deployment resumed"
+	case proto.VolumeSnapshotted:
+		message = fmt.Sprintf("Snapshot %s of volume '%s' created", event.Attributes["snapshot"], event.Attributes["volume"])
+	case proto.VolumeRestored:
+		message = fmt.Sprintf("Snapshot %s restored into volume '%s'", event.Attributes["snapshot"], event.Attributes["volume"])
+	case proto.DeploymentSeeded:
+		message = fmt.Sprintf("Volumes copied from deployment %s", event.Attributes["source"])
+	default:
+		message = event.Type
+	}
+
+	event.Severity = severity
+	event.Message = message
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+// subscriptionBufferSize is the number of events buffered for each
+// subscription before it is considered too slow and closed.
+var subscriptionBufferSize = 256
+
+// eventFilter selects the events of a subscription
+type eventFilter struct {
+	deployment string
+	task       string
+	types      []string
+}
+
+func (f *eventFilter) match(event *proto.Event2) bool {
+	if f.deployment != "" && f.deployment != event.Deployment {
+		return false
+	}
+	if f.task != "" && f.task != event.Task {
+		return false
+	}
+	if len(f.types) == 0 {
+		return true
+	}
+	for _, typ := range f.types {
+		if typ == event.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// eventBroker fans out the events stored by the server to the subscribers
+type eventBroker struct {
+	lock   sync.Mutex
+	subs   map[*subscription]struct{}
+	closed bool
+}
+
+type subscription struct {
+	broker *eventBroker
+	filter *eventFilter
+
+	// eventCh is closed when the subscription is closed, either by
+	// the subscriber, because it is too slow or because the broker stops.
+	eventCh chan *proto.Event2
+}
+
+func newEventBroker() *eventBroker {
+	return &eventBroker{
+		subs: map[*subscription]struct{}{},
+	}
+}
+
+func (b *eventBroker) subscribe(filter *eventFilter) *subscription {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	sub := &subscription{
+		broker:  b,
+		filter:  filter,
+		eventCh: make(chan *proto.Event2, subscriptionBufferSize),
+	}
+	if b.closed {
+		close(sub.eventCh)
+		return sub
+	}
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+func (b *eventBroker) publish(event *proto.Event2) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for sub := range b.subs {
+		if !sub.filter.match(event) {
+			continue
+		}
+		select {
+		case sub.eventCh <- event:
+		default:
+			// the subscriber is not consuming the events fast enough.
+			// It can resume from the last index it has seen.
+			b.removeLocked(sub)
+		}
+	}
+}
+
+func (b *eventBroker) close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for sub := range b.subs {
+		b.removeLocked(sub)
+	}
+	b.closed = true
+}
+
+func (b *eventBroker) removeLocked(sub *subscription) {
+	if _, ok := b.subs[sub]; !ok {
+		return
+	}
+	delete(b.subs, sub)
+	close(sub.eventCh)
+}
+
+func (s *subscription) close() {
+	s.broker.lock.Lock()
+	defer s.broker.lock.Unlock()
+
+	s.broker.removeLocked(s)
+}
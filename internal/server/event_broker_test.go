@@ -0,0 +1,47 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+func TestEventBroker_Filter(t *testing.T) {
+	b := newEventBroker()
+
+	sub := b.subscribe(&eventFilter{deployment: "a", types: []string{"start"}})
+
+	b.publish(&proto.Event2{Deployment: "b", Type: "start"})
+	b.publish(&proto.Event2{Deployment: "a", Type: "create"})
+	b.publish(&proto.Event2{Deployment: "a", Type: "start", Index: 3})
+
+	event := <-sub.eventCh
+	require.Equal(t, uint64(3), event.Index)
+	require.Len(t, sub.eventCh, 0)
+
+	b.close()
+
+	_, ok := <-sub.eventCh
+	require.False(t, ok)
+}
+
+func TestEventBroker_SlowSubscriber(t *testing.T) {
+	b := newEventBroker()
+
+	sub := b.subscribe(&eventFilter{})
+	for i := 0; i < subscriptionBufferSize+1; i++ {
+		b.publish(&proto.Event2{})
+	}
+
+	// the buffered events are received before the channel is closed
+	for i := 0; i < subscriptionBufferSize; i++ {
+		_, ok := <-sub.eventCh
+		require.True(t, ok)
+	}
+	_, ok := <-sub.eventCh
+	require.False(t, ok)
+
+	// closing the subscription again is a noop
+	sub.close()
+}
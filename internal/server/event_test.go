@@ -0,0 +1,41 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+func TestDescribeEvent(t *testing.T) {
+	cases := []struct {
+		typ      string
+		attrs    map[string]string
+		severity proto.Event2_Severity
+		message  string
+	}{
+		{"start", nil, proto.Event2_Info, "Task started"},
+		{"die", map[string]string{"exitCode": "0"}, proto.Event2_Info, "Task exited successfully"},
+		{"die", map[string]string{"exitCode": "137"}, proto.Event2_Error, "Task exited with code 137"},
+		{"kill", map[string]string{"signal": "9"}, proto.Event2_Warning, "Task killed with signal 9"},
+		{proto.TaskRestarting, nil, proto.Event2_Warning, "Restarting the task after a failure"},
+		{proto.TaskVerificationFailed, map[string]string{"source": "a", "reason": "invalid signature"}, proto.Event2_Error, "Failed to verify artifact 'a': invalid signature"},
+		{proto.DeploymentDiskPressure, map[string]string{"usage": "91"}, proto.Event2_Warning, "Host filesystem is 91% full"},
+		{"unknown", nil, proto.Event2_Info, "unknown"},
+	}
+	for _, c := range cases {
+		event := &proto.Event2{Type: c.typ, Attributes: c.attrs}
+		describeEvent(event)
+
+		require.NotZero(t, event.Timestamp)
+		require.Equal(t, c.severity, event.Severity)
+		require.Equal(t, c.message, event.Message)
+	}
+
+	// the message is not overwritten
+	event := &proto.Event2{Type: "start", Timestamp: 1, Message: "custom"}
+	describeEvent(event)
+
+	require.Equal(t, int64(1), event.Timestamp)
+	require.Equal(t, "custom", event.Message)
+}
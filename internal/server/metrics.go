@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/umbracle/vesta/internal/server/state2"
+)
+
+// stateCollector reports the number of rows of the tables of
+// the state on each scrape.
+type stateCollector struct {
+	state *state2.State
+	rows  *prometheus.Desc
+}
+
+func newStateCollector(state *state2.State) *stateCollector {
+	return &stateCollector{
+		state: state,
+		rows: prometheus.NewDesc(
+			"vesta_state_table_rows",
+			"Number of rows of each table of the state",
+			[]string{"table"}, nil,
+		),
+	}
+}
+
+func (c *stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.rows
+}
+
+func (c *stateCollector) Collect(ch chan<- prometheus.Metric) {
+	rows, err := c.state.TableRows()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.rows, err)
+		return
+	}
+	for table, num := range rows {
+		ch <- prometheus.MustNewConstMetric(c.rows, prometheus.GaugeValue, float64(num), table)
+	}
+}
+
+// metricsHandler returns the handler that exposes the metrics
+// of the server in the Prometheus format
+func (s *Server) metricsHandler() http.Handler {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(newStateCollector(s.state2))
+	registry.MustRegister(s.compactor.removed)
+	registry.MustRegister(s.disk)
+
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+func (s *Server) setupMetricsServer(addr string) error {
+	if addr == "" {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.metricsHandler())
+	s.metricsServer = &http.Server{Handler: mux}
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		if err := s.metricsServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+			s.logger.Error("failed to serve metrics server", "err", err)
+		}
+	}()
+
+	s.logger.Info("Metrics server started", "addr", addr)
+	return nil
+}
+
+func (s *Server) stopMetricsServer() {
+	if s.metricsServer != nil {
+		s.metricsServer.Shutdown(context.Background())
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/mock"
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+func TestMetricsHandler(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, _ := testServer(t, catalog)
+
+	_, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	srv.metricsHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	body, err := ioutil.ReadAll(rec.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), `vesta_state_table_rows{table="deployments"} 1`)
+	require.Contains(t, string(body), `vesta_state_table_rows{table="tasks"} 1`)
+	require.Contains(t, string(body), "vesta_events_compacted_total 0")
+	require.Contains(t, string(body), "vesta_host_disk_total_bytes")
+}
@@ -9,6 +9,33 @@ const (
 	TaskTerminated    = "Terminated"
 	TaskRestarting    = "Restarting"
 	TaskNotRestarting = "Not-restarting"
+
+	// TaskVerificationFailed is emitted when an artifact of a task
+	// does not match its sha256 digest or its signature
+	TaskVerificationFailed = "Verification-failed"
+
+	DeploymentDestroyed = "Destroyed"
+
+	// DeploymentDiskPressure is emitted when the host filesystem
+	// crosses the warning threshold
+	DeploymentDiskPressure = "Disk-pressure"
+
+	// DeploymentStopped and DeploymentResumed are emitted when the
+	// deployment is stopped because the host is out of disk and
+	// when it is resumed afterwards
+	DeploymentStopped = "Stopped"
+	DeploymentResumed = "Resumed"
+
+	VolumeSnapshotted = "Volume-snapshot"
+	VolumeRestored    = "Volume-restored"
+
+	// DeploymentSeeded is emitted when the volumes of a new
+	// deployment are copied from another deployment
+	DeploymentSeeded = "Seeded"
+
+	// DeploymentSeedFailed is emitted when the volumes of a new
+	// deployment cannot be copied. The deployment is destroyed.
+	DeploymentSeedFailed = "Seed-failed"
 )
 
 func (a *Allocation) Copy() *Allocation {
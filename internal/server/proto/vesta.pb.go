@@ -20,6 +20,164 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Health is the overall state of the tasks of the deployment
+type DeploymentStatusResponse_Health int32
+
+const (
+	DeploymentStatusResponse_Unknown DeploymentStatusResponse_Health = 0
+	// some tasks are not running yet
+	DeploymentStatusResponse_Pending DeploymentStatusResponse_Health = 1
+	// every task is running (or completed)
+	DeploymentStatusResponse_Healthy DeploymentStatusResponse_Health = 2
+	// some tasks are failing
+	DeploymentStatusResponse_Degraded DeploymentStatusResponse_Health = 3
+	// every task is failing
+	DeploymentStatusResponse_Unhealthy DeploymentStatusResponse_Health = 4
+)
+
+// Enum value maps for DeploymentStatusResponse_Health.
+var (
+	DeploymentStatusResponse_Health_name = map[int32]string{
+		0: "Unknown",
+		1: "Pending",
+		2: "Healthy",
+		3: "Degraded",
+		4: "Unhealthy",
+	}
+	DeploymentStatusResponse_Health_value = map[string]int32{
+		"Unknown":   0,
+		"Pending":   1,
+		"Healthy":   2,
+		"Degraded":  3,
+		"Unhealthy": 4,
+	}
+)
+
+func (x DeploymentStatusResponse_Health) Enum() *DeploymentStatusResponse_Health {
+	p := new(DeploymentStatusResponse_Health)
+	*p = x
+	return p
+}
+
+func (x DeploymentStatusResponse_Health) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeploymentStatusResponse_Health) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_server_proto_vesta_proto_enumTypes[0].Descriptor()
+}
+
+func (DeploymentStatusResponse_Health) Type() protoreflect.EnumType {
+	return &file_internal_server_proto_vesta_proto_enumTypes[0]
+}
+
+func (x DeploymentStatusResponse_Health) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeploymentStatusResponse_Health.Descriptor instead.
+func (DeploymentStatusResponse_Health) EnumDescriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{7, 0}
+}
+
+type TaskLogsResponse_Stream int32
+
+const (
+	TaskLogsResponse_Stdout TaskLogsResponse_Stream = 0
+	TaskLogsResponse_Stderr TaskLogsResponse_Stream = 1
+)
+
+// Enum value maps for TaskLogsResponse_Stream.
+var (
+	TaskLogsResponse_Stream_name = map[int32]string{
+		0: "Stdout",
+		1: "Stderr",
+	}
+	TaskLogsResponse_Stream_value = map[string]int32{
+		"Stdout": 0,
+		"Stderr": 1,
+	}
+)
+
+func (x TaskLogsResponse_Stream) Enum() *TaskLogsResponse_Stream {
+	p := new(TaskLogsResponse_Stream)
+	*p = x
+	return p
+}
+
+func (x TaskLogsResponse_Stream) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskLogsResponse_Stream) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_server_proto_vesta_proto_enumTypes[1].Descriptor()
+}
+
+func (TaskLogsResponse_Stream) Type() protoreflect.EnumType {
+	return &file_internal_server_proto_vesta_proto_enumTypes[1]
+}
+
+func (x TaskLogsResponse_Stream) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskLogsResponse_Stream.Descriptor instead.
+func (TaskLogsResponse_Stream) EnumDescriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{22, 0}
+}
+
+type TaskDiff_Type int32
+
+const (
+	TaskDiff_Unchanged TaskDiff_Type = 0
+	TaskDiff_Create    TaskDiff_Type = 1
+	TaskDiff_Replace   TaskDiff_Type = 2
+	TaskDiff_Destroy   TaskDiff_Type = 3
+)
+
+// Enum value maps for TaskDiff_Type.
+var (
+	TaskDiff_Type_name = map[int32]string{
+		0: "Unchanged",
+		1: "Create",
+		2: "Replace",
+		3: "Destroy",
+	}
+	TaskDiff_Type_value = map[string]int32{
+		"Unchanged": 0,
+		"Create":    1,
+		"Replace":   2,
+		"Destroy":   3,
+	}
+)
+
+func (x TaskDiff_Type) Enum() *TaskDiff_Type {
+	p := new(TaskDiff_Type)
+	*p = x
+	return p
+}
+
+func (x TaskDiff_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskDiff_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_server_proto_vesta_proto_enumTypes[2].Descriptor()
+}
+
+func (TaskDiff_Type) Type() protoreflect.EnumType {
+	return &file_internal_server_proto_vesta_proto_enumTypes[2]
+}
+
+func (x TaskDiff_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskDiff_Type.Descriptor instead.
+func (TaskDiff_Type) EnumDescriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{30, 0}
+}
+
 type Allocation_Status int32
 
 const (
@@ -56,11 +214,11 @@ func (x Allocation_Status) String() string {
 }
 
 func (Allocation_Status) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_server_proto_vesta_proto_enumTypes[0].Descriptor()
+	return file_internal_server_proto_vesta_proto_enumTypes[3].Descriptor()
 }
 
 func (Allocation_Status) Type() protoreflect.EnumType {
-	return &file_internal_server_proto_vesta_proto_enumTypes[0]
+	return &file_internal_server_proto_vesta_proto_enumTypes[3]
 }
 
 func (x Allocation_Status) Number() protoreflect.EnumNumber {
@@ -69,7 +227,7 @@ func (x Allocation_Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Allocation_Status.Descriptor instead.
 func (Allocation_Status) EnumDescriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{15, 0}
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{34, 0}
 }
 
 type Allocation_DesiredStatus int32
@@ -102,11 +260,11 @@ func (x Allocation_DesiredStatus) String() string {
 }
 
 func (Allocation_DesiredStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_server_proto_vesta_proto_enumTypes[1].Descriptor()
+	return file_internal_server_proto_vesta_proto_enumTypes[4].Descriptor()
 }
 
 func (Allocation_DesiredStatus) Type() protoreflect.EnumType {
-	return &file_internal_server_proto_vesta_proto_enumTypes[1]
+	return &file_internal_server_proto_vesta_proto_enumTypes[4]
 }
 
 func (x Allocation_DesiredStatus) Number() protoreflect.EnumNumber {
@@ -115,7 +273,7 @@ func (x Allocation_DesiredStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Allocation_DesiredStatus.Descriptor instead.
 func (Allocation_DesiredStatus) EnumDescriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{15, 1}
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{34, 1}
 }
 
 type TaskState_State int32
@@ -151,11 +309,11 @@ func (x TaskState_State) String() string {
 }
 
 func (TaskState_State) Descriptor() protoreflect.EnumDescriptor {
-	return file_internal_server_proto_vesta_proto_enumTypes[2].Descriptor()
+	return file_internal_server_proto_vesta_proto_enumTypes[5].Descriptor()
 }
 
 func (TaskState_State) Type() protoreflect.EnumType {
-	return &file_internal_server_proto_vesta_proto_enumTypes[2]
+	return &file_internal_server_proto_vesta_proto_enumTypes[5]
 }
 
 func (x TaskState_State) Number() protoreflect.EnumNumber {
@@ -164,7 +322,165 @@ func (x TaskState_State) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use TaskState_State.Descriptor instead.
 func (TaskState_State) EnumDescriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{16, 0}
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{35, 0}
+}
+
+type Deployment2_Status int32
+
+const (
+	Deployment2_Running   Deployment2_Status = 0
+	Deployment2_Destroyed Deployment2_Status = 1
+	// the tasks are stopped, see stopReason
+	Deployment2_Stopped Deployment2_Status = 2
+)
+
+// Enum value maps for Deployment2_Status.
+var (
+	Deployment2_Status_name = map[int32]string{
+		0: "Running",
+		1: "Destroyed",
+		2: "Stopped",
+	}
+	Deployment2_Status_value = map[string]int32{
+		"Running":   0,
+		"Destroyed": 1,
+		"Stopped":   2,
+	}
+)
+
+func (x Deployment2_Status) Enum() *Deployment2_Status {
+	p := new(Deployment2_Status)
+	*p = x
+	return p
+}
+
+func (x Deployment2_Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Deployment2_Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_server_proto_vesta_proto_enumTypes[6].Descriptor()
+}
+
+func (Deployment2_Status) Type() protoreflect.EnumType {
+	return &file_internal_server_proto_vesta_proto_enumTypes[6]
+}
+
+func (x Deployment2_Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Deployment2_Status.Descriptor instead.
+func (Deployment2_Status) EnumDescriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{36, 0}
+}
+
+type TaskState2_State int32
+
+const (
+	// the container of the task is not running yet
+	TaskState2_Pending TaskState2_State = 0
+	TaskState2_Running TaskState2_State = 1
+	// the task is being restarted after a failure
+	TaskState2_Restarting TaskState2_State = 2
+	// the task failed and waits to be restarted
+	TaskState2_Dead TaskState2_State = 3
+	// the batch task finished successfully
+	TaskState2_Completed TaskState2_State = 4
+)
+
+// Enum value maps for TaskState2_State.
+var (
+	TaskState2_State_name = map[int32]string{
+		0: "Pending",
+		1: "Running",
+		2: "Restarting",
+		3: "Dead",
+		4: "Completed",
+	}
+	TaskState2_State_value = map[string]int32{
+		"Pending":    0,
+		"Running":    1,
+		"Restarting": 2,
+		"Dead":       3,
+		"Completed":  4,
+	}
+)
+
+func (x TaskState2_State) Enum() *TaskState2_State {
+	p := new(TaskState2_State)
+	*p = x
+	return p
+}
+
+func (x TaskState2_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskState2_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_server_proto_vesta_proto_enumTypes[7].Descriptor()
+}
+
+func (TaskState2_State) Type() protoreflect.EnumType {
+	return &file_internal_server_proto_vesta_proto_enumTypes[7]
+}
+
+func (x TaskState2_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskState2_State.Descriptor instead.
+func (TaskState2_State) EnumDescriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{38, 0}
+}
+
+type Event2_Severity int32
+
+const (
+	Event2_Info    Event2_Severity = 0
+	Event2_Warning Event2_Severity = 1
+	Event2_Error   Event2_Severity = 2
+)
+
+// Enum value maps for Event2_Severity.
+var (
+	Event2_Severity_name = map[int32]string{
+		0: "Info",
+		1: "Warning",
+		2: "Error",
+	}
+	Event2_Severity_value = map[string]int32{
+		"Info":    0,
+		"Warning": 1,
+		"Error":   2,
+	}
+)
+
+func (x Event2_Severity) Enum() *Event2_Severity {
+	p := new(Event2_Severity)
+	*p = x
+	return p
+}
+
+func (x Event2_Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Event2_Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_server_proto_vesta_proto_enumTypes[8].Descriptor()
+}
+
+func (Event2_Severity) Type() protoreflect.EnumType {
+	return &file_internal_server_proto_vesta_proto_enumTypes[8]
+}
+
+func (x Event2_Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Event2_Severity.Descriptor instead.
+func (Event2_Severity) EnumDescriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{39, 0}
 }
 
 type CatalogListRequest struct {
@@ -352,6 +668,8 @@ type DestroyRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// remove the volumes of the deployment
+	PurgeVolumes bool `protobuf:"varint,2,opt,name=purgeVolumes,proto3" json:"purgeVolumes,omitempty"`
 }
 
 func (x *DestroyRequest) Reset() {
@@ -393,6 +711,13 @@ func (x *DestroyRequest) GetId() string {
 	return ""
 }
 
+func (x *DestroyRequest) GetPurgeVolumes() bool {
+	if x != nil {
+		return x.PurgeVolumes
+	}
+	return false
+}
+
 type DestroyResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -483,8 +808,15 @@ type DeploymentStatusResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Allocation *Deployment2 `protobuf:"bytes,1,opt,name=allocation,proto3" json:"allocation,omitempty"`
-	Events     []*Event2    `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	Allocation *Deployment2                    `protobuf:"bytes,1,opt,name=allocation,proto3" json:"allocation,omitempty"`
+	Events     []*Event2                       `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	Tasks      []*TaskState2                   `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Health     DeploymentStatusResponse_Health `protobuf:"varint,4,opt,name=health,proto3,enum=proto.DeploymentStatusResponse_Health" json:"health,omitempty"`
+	// size in bytes of the volumes of the deployment
+	DiskUsage uint64 `protobuf:"varint,5,opt,name=diskUsage,proto3" json:"diskUsage,omitempty"`
+	// size in bytes that the plugin expects for the chain
+	// of the deployment. Zero if the plugin does not declare it.
+	ExpectedDiskSize uint64 `protobuf:"varint,6,opt,name=expectedDiskSize,proto3" json:"expectedDiskSize,omitempty"`
 }
 
 func (x *DeploymentStatusResponse) Reset() {
@@ -533,14 +865,44 @@ func (x *DeploymentStatusResponse) GetEvents() []*Event2 {
 	return nil
 }
 
-type ListDeploymentRequest struct {
+func (x *DeploymentStatusResponse) GetTasks() []*TaskState2 {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *DeploymentStatusResponse) GetHealth() DeploymentStatusResponse_Health {
+	if x != nil {
+		return x.Health
+	}
+	return DeploymentStatusResponse_Unknown
+}
+
+func (x *DeploymentStatusResponse) GetDiskUsage() uint64 {
+	if x != nil {
+		return x.DiskUsage
+	}
+	return 0
+}
+
+func (x *DeploymentStatusResponse) GetExpectedDiskSize() uint64 {
+	if x != nil {
+		return x.ExpectedDiskSize
+	}
+	return 0
+}
+
+type DeploymentHistoryRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *ListDeploymentRequest) Reset() {
-	*x = ListDeploymentRequest{}
+func (x *DeploymentHistoryRequest) Reset() {
+	*x = DeploymentHistoryRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_server_proto_vesta_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -548,13 +910,13 @@ func (x *ListDeploymentRequest) Reset() {
 	}
 }
 
-func (x *ListDeploymentRequest) String() string {
+func (x *DeploymentHistoryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDeploymentRequest) ProtoMessage() {}
+func (*DeploymentHistoryRequest) ProtoMessage() {}
 
-func (x *ListDeploymentRequest) ProtoReflect() protoreflect.Message {
+func (x *DeploymentHistoryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_server_proto_vesta_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -566,21 +928,28 @@ func (x *ListDeploymentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDeploymentRequest.ProtoReflect.Descriptor instead.
-func (*ListDeploymentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeploymentHistoryRequest.ProtoReflect.Descriptor instead.
+func (*DeploymentHistoryRequest) Descriptor() ([]byte, []int) {
 	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{8}
 }
 
-type ListDeploymentResponse struct {
+func (x *DeploymentHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeploymentHistoryResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Allocations []*Deployment2 `protobuf:"bytes,1,rep,name=allocations,proto3" json:"allocations,omitempty"`
+	Revisions []*DeploymentRevision `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
 }
 
-func (x *ListDeploymentResponse) Reset() {
-	*x = ListDeploymentResponse{}
+func (x *DeploymentHistoryResponse) Reset() {
+	*x = DeploymentHistoryResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_server_proto_vesta_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -588,13 +957,13 @@ func (x *ListDeploymentResponse) Reset() {
 	}
 }
 
-func (x *ListDeploymentResponse) String() string {
+func (x *DeploymentHistoryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListDeploymentResponse) ProtoMessage() {}
+func (*DeploymentHistoryResponse) ProtoMessage() {}
 
-func (x *ListDeploymentResponse) ProtoReflect() protoreflect.Message {
+func (x *DeploymentHistoryResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_server_proto_vesta_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -606,37 +975,30 @@ func (x *ListDeploymentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListDeploymentResponse.ProtoReflect.Descriptor instead.
-func (*ListDeploymentResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeploymentHistoryResponse.ProtoReflect.Descriptor instead.
+func (*DeploymentHistoryResponse) Descriptor() ([]byte, []int) {
 	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ListDeploymentResponse) GetAllocations() []*Deployment2 {
+func (x *DeploymentHistoryResponse) GetRevisions() []*DeploymentRevision {
 	if x != nil {
-		return x.Allocations
+		return x.Revisions
 	}
 	return nil
 }
 
-type ApplyRequest struct {
+type DeploymentRollbackRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// id name of the action
-	Action string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
-	// input for the action
-	Input []byte `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
-	// name of the allocation to modify
-	AllocationId string `protobuf:"bytes,3,opt,name=allocationId,proto3" json:"allocationId,omitempty"`
-	Metrics      bool   `protobuf:"varint,4,opt,name=metrics,proto3" json:"metrics,omitempty"`
-	Chain        string `protobuf:"bytes,5,opt,name=chain,proto3" json:"chain,omitempty"`
-	Alias        string `protobuf:"bytes,6,opt,name=alias,proto3" json:"alias,omitempty"`
-	LogLevel     string `protobuf:"bytes,7,opt,name=logLevel,proto3" json:"logLevel,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// revision to roll back to
+	Revision uint64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
 }
 
-func (x *ApplyRequest) Reset() {
-	*x = ApplyRequest{}
+func (x *DeploymentRollbackRequest) Reset() {
+	*x = DeploymentRollbackRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_server_proto_vesta_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -644,13 +1006,13 @@ func (x *ApplyRequest) Reset() {
 	}
 }
 
-func (x *ApplyRequest) String() string {
+func (x *DeploymentRollbackRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApplyRequest) ProtoMessage() {}
+func (*DeploymentRollbackRequest) ProtoMessage() {}
 
-func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
+func (x *DeploymentRollbackRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_server_proto_vesta_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -662,85 +1024,1794 @@ func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApplyRequest.ProtoReflect.Descriptor instead.
-func (*ApplyRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeploymentRollbackRequest.ProtoReflect.Descriptor instead.
+func (*DeploymentRollbackRequest) Descriptor() ([]byte, []int) {
 	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ApplyRequest) GetAction() string {
+func (x *DeploymentRollbackRequest) GetId() string {
 	if x != nil {
-		return x.Action
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ApplyRequest) GetInput() []byte {
+func (x *DeploymentRollbackRequest) GetRevision() uint64 {
 	if x != nil {
-		return x.Input
+		return x.Revision
 	}
-	return nil
+	return 0
 }
 
-func (x *ApplyRequest) GetAllocationId() string {
-	if x != nil {
-		return x.AllocationId
-	}
-	return ""
+type DeploymentRollbackResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// new revision created by the rollback
+	Revision uint64 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	// changes applied to the tasks of the deployment
+	Tasks []*TaskDiff `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
 }
 
-func (x *ApplyRequest) GetMetrics() bool {
-	if x != nil {
-		return x.Metrics
+func (x *DeploymentRollbackResponse) Reset() {
+	*x = DeploymentRollbackResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return false
 }
 
-func (x *ApplyRequest) GetChain() string {
+func (x *DeploymentRollbackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentRollbackResponse) ProtoMessage() {}
+
+func (x *DeploymentRollbackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentRollbackResponse.ProtoReflect.Descriptor instead.
+func (*DeploymentRollbackResponse) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeploymentRollbackResponse) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *DeploymentRollbackResponse) GetTasks() []*TaskDiff {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type VolumeSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id (or alias) of the deployment
+	Deployment string `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	Task       string `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	Volume     string `protobuf:"bytes,3,opt,name=volume,proto3" json:"volume,omitempty"`
+	// path of the snapshot file in the snapshots folder of the
+	// data directory. It defaults to a file named after the snapshot.
+	Path string `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	// compress the snapshot with zstd
+	Compress bool `protobuf:"varint,5,opt,name=compress,proto3" json:"compress,omitempty"`
+}
+
+func (x *VolumeSnapshotRequest) Reset() {
+	*x = VolumeSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VolumeSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeSnapshotRequest) ProtoMessage() {}
+
+func (x *VolumeSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*VolumeSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VolumeSnapshotRequest) GetDeployment() string {
+	if x != nil {
+		return x.Deployment
+	}
+	return ""
+}
+
+func (x *VolumeSnapshotRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *VolumeSnapshotRequest) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *VolumeSnapshotRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *VolumeSnapshotRequest) GetCompress() bool {
+	if x != nil {
+		return x.Compress
+	}
+	return false
+}
+
+type VolumeSnapshotResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshot *Snapshot `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+}
+
+func (x *VolumeSnapshotResponse) Reset() {
+	*x = VolumeSnapshotResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VolumeSnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeSnapshotResponse) ProtoMessage() {}
+
+func (x *VolumeSnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeSnapshotResponse.ProtoReflect.Descriptor instead.
+func (*VolumeSnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *VolumeSnapshotResponse) GetSnapshot() *Snapshot {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+type VolumeRestoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id (or prefix) of the snapshot
+	Snapshot string `protobuf:"bytes,1,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	// id (or alias) of the deployment to restore the snapshot into.
+	// It defaults to the deployment of the snapshot.
+	Deployment string `protobuf:"bytes,2,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	// task and volume to restore the snapshot into. They default
+	// to the task and the volume of the snapshot.
+	Task   string `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	Volume string `protobuf:"bytes,4,opt,name=volume,proto3" json:"volume,omitempty"`
+}
+
+func (x *VolumeRestoreRequest) Reset() {
+	*x = VolumeRestoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VolumeRestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeRestoreRequest) ProtoMessage() {}
+
+func (x *VolumeRestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeRestoreRequest.ProtoReflect.Descriptor instead.
+func (*VolumeRestoreRequest) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *VolumeRestoreRequest) GetSnapshot() string {
+	if x != nil {
+		return x.Snapshot
+	}
+	return ""
+}
+
+func (x *VolumeRestoreRequest) GetDeployment() string {
+	if x != nil {
+		return x.Deployment
+	}
+	return ""
+}
+
+func (x *VolumeRestoreRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *VolumeRestoreRequest) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+type VolumeRestoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *VolumeRestoreResponse) Reset() {
+	*x = VolumeRestoreResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VolumeRestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeRestoreResponse) ProtoMessage() {}
+
+func (x *VolumeRestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeRestoreResponse.ProtoReflect.Descriptor instead.
+func (*VolumeRestoreResponse) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{15}
+}
+
+type VolumeListRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id (or alias) of the deployment, empty for all the deployments
+	Deployment string `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+}
+
+func (x *VolumeListRequest) Reset() {
+	*x = VolumeListRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VolumeListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeListRequest) ProtoMessage() {}
+
+func (x *VolumeListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeListRequest.ProtoReflect.Descriptor instead.
+func (*VolumeListRequest) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *VolumeListRequest) GetDeployment() string {
+	if x != nil {
+		return x.Deployment
+	}
+	return ""
+}
+
+type VolumeListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshots []*Snapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+}
+
+func (x *VolumeListResponse) Reset() {
+	*x = VolumeListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VolumeListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeListResponse) ProtoMessage() {}
+
+func (x *VolumeListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeListResponse.ProtoReflect.Descriptor instead.
+func (*VolumeListResponse) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *VolumeListResponse) GetSnapshots() []*Snapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+// Snapshot is an archive of a volume of a task
+type Snapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Deployment string `protobuf:"bytes,2,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	Task       string `protobuf:"bytes,3,opt,name=task,proto3" json:"task,omitempty"`
+	Volume     string `protobuf:"bytes,4,opt,name=volume,proto3" json:"volume,omitempty"`
+	// path of the archive in the host
+	Path string `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	// size of the archive in bytes
+	Size       uint64 `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	Compressed bool   `protobuf:"varint,7,opt,name=compressed,proto3" json:"compressed,omitempty"`
+	CreatedAt  int64  `protobuf:"varint,8,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Snapshot) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Snapshot) GetDeployment() string {
+	if x != nil {
+		return x.Deployment
+	}
+	return ""
+}
+
+func (x *Snapshot) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *Snapshot) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *Snapshot) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Snapshot) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Snapshot) GetCompressed() bool {
+	if x != nil {
+		return x.Compressed
+	}
+	return false
+}
+
+func (x *Snapshot) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+type WatchEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id (or alias) of the deployment, empty for all the deployments
+	Deployment string `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	// name of the task, empty for all the tasks
+	Task string `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	// types of the events, empty for all the types
+	Types []string `protobuf:"bytes,3,rep,name=types,proto3" json:"types,omitempty"`
+	// index of the last event seen, only newer events are returned
+	Index uint64 `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+	// follow keeps the stream open for new events
+	Follow bool `protobuf:"varint,5,opt,name=follow,proto3" json:"follow,omitempty"`
+}
+
+func (x *WatchEventsRequest) Reset() {
+	*x = WatchEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsRequest) ProtoMessage() {}
+
+func (x *WatchEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsRequest.ProtoReflect.Descriptor instead.
+func (*WatchEventsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WatchEventsRequest) GetDeployment() string {
+	if x != nil {
+		return x.Deployment
+	}
+	return ""
+}
+
+func (x *WatchEventsRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *WatchEventsRequest) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *WatchEventsRequest) GetIndex() uint64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *WatchEventsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+type WatchEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Event *Event2 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *WatchEventsResponse) Reset() {
+	*x = WatchEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchEventsResponse) ProtoMessage() {}
+
+func (x *WatchEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchEventsResponse.ProtoReflect.Descriptor instead.
+func (*WatchEventsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *WatchEventsResponse) GetEvent() *Event2 {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+type TaskLogsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id (or alias) of the deployment
+	Deployment string `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	// name of the task
+	Task string `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	// follow keeps the stream open for new logs
+	Follow bool `protobuf:"varint,3,opt,name=follow,proto3" json:"follow,omitempty"`
+	// number of lines from the end of the logs, empty for all
+	Tail string `protobuf:"bytes,4,opt,name=tail,proto3" json:"tail,omitempty"`
+	// unix timestamp, only the logs after it are returned
+	Since int64 `protobuf:"varint,5,opt,name=since,proto3" json:"since,omitempty"`
+}
+
+func (x *TaskLogsRequest) Reset() {
+	*x = TaskLogsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskLogsRequest) ProtoMessage() {}
+
+func (x *TaskLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskLogsRequest.ProtoReflect.Descriptor instead.
+func (*TaskLogsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TaskLogsRequest) GetDeployment() string {
+	if x != nil {
+		return x.Deployment
+	}
+	return ""
+}
+
+func (x *TaskLogsRequest) GetTask() string {
+	if x != nil {
+		return x.Task
+	}
+	return ""
+}
+
+func (x *TaskLogsRequest) GetFollow() bool {
+	if x != nil {
+		return x.Follow
+	}
+	return false
+}
+
+func (x *TaskLogsRequest) GetTail() string {
+	if x != nil {
+		return x.Tail
+	}
+	return ""
+}
+
+func (x *TaskLogsRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+type TaskLogsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stream TaskLogsResponse_Stream `protobuf:"varint,1,opt,name=stream,proto3,enum=proto.TaskLogsResponse_Stream" json:"stream,omitempty"`
+	Data   []byte                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *TaskLogsResponse) Reset() {
+	*x = TaskLogsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskLogsResponse) ProtoMessage() {}
+
+func (x *TaskLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskLogsResponse.ProtoReflect.Descriptor instead.
+func (*TaskLogsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *TaskLogsResponse) GetStream() TaskLogsResponse_Stream {
+	if x != nil {
+		return x.Stream
+	}
+	return TaskLogsResponse_Stdout
+}
+
+func (x *TaskLogsResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type TaskExecRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// setup is set only on the first message of the stream
+	Setup *TaskExecRequest_Setup `protobuf:"bytes,1,opt,name=setup,proto3" json:"setup,omitempty"`
+	// input of the command
+	Stdin []byte `protobuf:"bytes,2,opt,name=stdin,proto3" json:"stdin,omitempty"`
+	// stdinClose signals that there is no more input
+	StdinClose bool `protobuf:"varint,3,opt,name=stdinClose,proto3" json:"stdinClose,omitempty"`
+	// resize is the new size of the terminal
+	Resize *TaskExecRequest_TerminalSize `protobuf:"bytes,4,opt,name=resize,proto3" json:"resize,omitempty"`
+}
+
+func (x *TaskExecRequest) Reset() {
+	*x = TaskExecRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskExecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskExecRequest) ProtoMessage() {}
+
+func (x *TaskExecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskExecRequest.ProtoReflect.Descriptor instead.
+func (*TaskExecRequest) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TaskExecRequest) GetSetup() *TaskExecRequest_Setup {
+	if x != nil {
+		return x.Setup
+	}
+	return nil
+}
+
+func (x *TaskExecRequest) GetStdin() []byte {
+	if x != nil {
+		return x.Stdin
+	}
+	return nil
+}
+
+func (x *TaskExecRequest) GetStdinClose() bool {
+	if x != nil {
+		return x.StdinClose
+	}
+	return false
+}
+
+func (x *TaskExecRequest) GetResize() *TaskExecRequest_TerminalSize {
+	if x != nil {
+		return x.Resize
+	}
+	return nil
+}
+
+type TaskExecResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stdout []byte `protobuf:"bytes,1,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr []byte `protobuf:"bytes,2,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	// exited is set on the last message once the command finishes
+	Exited   bool  `protobuf:"varint,3,opt,name=exited,proto3" json:"exited,omitempty"`
+	ExitCode int32 `protobuf:"varint,4,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+}
+
+func (x *TaskExecResponse) Reset() {
+	*x = TaskExecResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskExecResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskExecResponse) ProtoMessage() {}
+
+func (x *TaskExecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskExecResponse.ProtoReflect.Descriptor instead.
+func (*TaskExecResponse) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *TaskExecResponse) GetStdout() []byte {
+	if x != nil {
+		return x.Stdout
+	}
+	return nil
+}
+
+func (x *TaskExecResponse) GetStderr() []byte {
+	if x != nil {
+		return x.Stderr
+	}
+	return nil
+}
+
+func (x *TaskExecResponse) GetExited() bool {
+	if x != nil {
+		return x.Exited
+	}
+	return false
+}
+
+func (x *TaskExecResponse) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+type ListDeploymentRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListDeploymentRequest) Reset() {
+	*x = ListDeploymentRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeploymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeploymentRequest) ProtoMessage() {}
+
+func (x *ListDeploymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeploymentRequest.ProtoReflect.Descriptor instead.
+func (*ListDeploymentRequest) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{25}
+}
+
+type ListDeploymentResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Allocations []*Deployment2 `protobuf:"bytes,1,rep,name=allocations,proto3" json:"allocations,omitempty"`
+}
+
+func (x *ListDeploymentResponse) Reset() {
+	*x = ListDeploymentResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListDeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeploymentResponse) ProtoMessage() {}
+
+func (x *ListDeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeploymentResponse.ProtoReflect.Descriptor instead.
+func (*ListDeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListDeploymentResponse) GetAllocations() []*Deployment2 {
+	if x != nil {
+		return x.Allocations
+	}
+	return nil
+}
+
+type ApplyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id name of the action
+	Action string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	// input for the action
+	Input []byte `protobuf:"bytes,2,opt,name=input,proto3" json:"input,omitempty"`
+	// name of the allocation to modify
+	AllocationId string `protobuf:"bytes,3,opt,name=allocationId,proto3" json:"allocationId,omitempty"`
+	// metrics is not set on updates that do not change it
+	Metrics  *bool  `protobuf:"varint,4,opt,name=metrics,proto3,oneof" json:"metrics,omitempty"`
+	Chain    string `protobuf:"bytes,5,opt,name=chain,proto3" json:"chain,omitempty"`
+	Alias    string `protobuf:"bytes,6,opt,name=alias,proto3" json:"alias,omitempty"`
+	LogLevel string `protobuf:"bytes,7,opt,name=logLevel,proto3" json:"logLevel,omitempty"`
+	// dryRun computes the changes without applying them
+	DryRun bool `protobuf:"varint,8,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	// fromDeployment is the deployment whose volumes seed
+	// the volumes of a new deployment
+	FromDeployment string `protobuf:"bytes,9,opt,name=fromDeployment,proto3" json:"fromDeployment,omitempty"`
+	// fromSnapshot is the snapshot restored in the volume
+	// of a new deployment before its tasks start
+	FromSnapshot string `protobuf:"bytes,10,opt,name=fromSnapshot,proto3" json:"fromSnapshot,omitempty"`
+}
+
+func (x *ApplyRequest) Reset() {
+	*x = ApplyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyRequest) ProtoMessage() {}
+
+func (x *ApplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyRequest.ProtoReflect.Descriptor instead.
+func (*ApplyRequest) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ApplyRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetInput() []byte {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *ApplyRequest) GetAllocationId() string {
+	if x != nil {
+		return x.AllocationId
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetMetrics() bool {
+	if x != nil && x.Metrics != nil {
+		return *x.Metrics
+	}
+	return false
+}
+
+func (x *ApplyRequest) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetLogLevel() string {
+	if x != nil {
+		return x.LogLevel
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ApplyRequest) GetFromDeployment() string {
+	if x != nil {
+		return x.FromDeployment
+	}
+	return ""
+}
+
+func (x *ApplyRequest) GetFromSnapshot() string {
+	if x != nil {
+		return x.FromSnapshot
+	}
+	return ""
+}
+
+type ApplyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// changes applied to the tasks of the deployment
+	Tasks []*TaskDiff `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// plan of the changes if the request is a dry run
+	Plan *Plan `protobuf:"bytes,3,opt,name=plan,proto3" json:"plan,omitempty"`
+}
+
+func (x *ApplyResponse) Reset() {
+	*x = ApplyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ApplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyResponse) ProtoMessage() {}
+
+func (x *ApplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyResponse.ProtoReflect.Descriptor instead.
+func (*ApplyResponse) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ApplyResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApplyResponse) GetTasks() []*TaskDiff {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ApplyResponse) GetPlan() *Plan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+// Plan describes the changes of an Apply request without running them
+type Plan struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// tasks rendered by the plugin
+	Tasks map[string]*Task `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// changes in the input with respect to the stored spec
+	Fields []*Plan_FieldDiff `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	// blocked is set if a force_new field changes
+	Blocked bool `protobuf:"varint,3,opt,name=blocked,proto3" json:"blocked,omitempty"`
+}
+
+func (x *Plan) Reset() {
+	*x = Plan{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Plan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Plan) ProtoMessage() {}
+
+func (x *Plan) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Plan.ProtoReflect.Descriptor instead.
+func (*Plan) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *Plan) GetTasks() map[string]*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *Plan) GetFields() []*Plan_FieldDiff {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Plan) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+// TaskDiff is the change of a single task of a deployment
+type TaskDiff struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type TaskDiff_Type `protobuf:"varint,2,opt,name=type,proto3,enum=proto.TaskDiff_Type" json:"type,omitempty"`
+	// fields of the task that changed
+	Fields []string `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *TaskDiff) Reset() {
+	*x = TaskDiff{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskDiff) ProtoMessage() {}
+
+func (x *TaskDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskDiff.ProtoReflect.Descriptor instead.
+func (*TaskDiff) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TaskDiff) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TaskDiff) GetType() TaskDiff_Type {
+	if x != nil {
+		return x.Type
+	}
+	return TaskDiff_Unchanged
+}
+
+func (x *TaskDiff) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// Provisonal name, item is an item on the catalog
+type Item struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Fields  []*Item_Field `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	Chains  []string      `protobuf:"bytes,3,rep,name=chains,proto3" json:"chains,omitempty"`
+	Version string        `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// expected disk size in bytes for each chain
+	DiskSize map[string]uint64 `protobuf:"bytes,5,rep,name=diskSize,proto3" json:"diskSize,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Item) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Item) GetFields() []*Item_Field {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *Item) GetChains() []string {
+	if x != nil {
+		return x.Chains
+	}
+	return nil
+}
+
+func (x *Item) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Item) GetDiskSize() map[string]uint64 {
+	if x != nil {
+		return x.DiskSize
+	}
+	return nil
+}
+
+// Node1 is a node that can allocate resources
+type Node struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Datacenter string `protobuf:"bytes,2,opt,name=datacenter,proto3" json:"datacenter,omitempty"`
+	Name       string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Node) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Node) GetDatacenter() string {
+	if x != nil {
+		return x.Datacenter
+	}
+	return ""
+}
+
+func (x *Node) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Task represents an single container process
+type Task struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Image       string            `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Tag         string            `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Args        []string          `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	Env         map[string]string `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Labels      map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SecurityOpt []string          `protobuf:"bytes,7,rep,name=securityOpt,proto3" json:"securityOpt,omitempty"`
+	// list of data access for this file
+	Data      map[string]string       `protobuf:"bytes,9,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Volumes   map[string]*Task_Volume `protobuf:"bytes,11,rep,name=volumes,proto3" json:"volumes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Telemetry *Task_Telemetry         `protobuf:"bytes,12,opt,name=telemetry,proto3" json:"telemetry,omitempty"`
+	Artifacts []*Task_Artifact        `protobuf:"bytes,13,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
+	Batch     bool                    `protobuf:"varint,14,opt,name=batch,proto3" json:"batch,omitempty"`
+	// paths of the data files that contain secrets. They are
+	// redacted in the responses of the API.
+	SecretData []string `protobuf:"bytes,15,rep,name=secretData,proto3" json:"secretData,omitempty"`
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Task) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Task) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *Task) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *Task) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *Task) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Task) GetSecurityOpt() []string {
+	if x != nil {
+		return x.SecurityOpt
+	}
+	return nil
+}
+
+func (x *Task) GetData() map[string]string {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Task) GetVolumes() map[string]*Task_Volume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *Task) GetTelemetry() *Task_Telemetry {
+	if x != nil {
+		return x.Telemetry
+	}
+	return nil
+}
+
+func (x *Task) GetArtifacts() []*Task_Artifact {
+	if x != nil {
+		return x.Artifacts
+	}
+	return nil
+}
+
+func (x *Task) GetBatch() bool {
+	if x != nil {
+		return x.Batch
+	}
+	return false
+}
+
+func (x *Task) GetSecretData() []string {
+	if x != nil {
+		return x.SecretData
+	}
+	return nil
+}
+
+// Allocation represents an allocation of a deployment
+type Allocation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id of the allocation
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// node to which the allocation is assigned
+	NodeId string `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	// tasks to run on the allocation
+	Tasks map[string]*Task `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// state of each of the tasks
+	TaskStates map[string]*TaskState `protobuf:"bytes,4,rep,name=taskStates,proto3" json:"taskStates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// status of the allocation
+	Status     Allocation_Status                 `protobuf:"varint,5,opt,name=status,proto3,enum=proto.Allocation_Status" json:"status,omitempty"`
+	SyncStatus map[string]*Allocation_SyncStatus `protobuf:"bytes,6,rep,name=syncStatus,proto3" json:"syncStatus,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// desired status of the allocation
+	DesiredStatus Allocation_DesiredStatus `protobuf:"varint,7,opt,name=desiredStatus,proto3,enum=proto.Allocation_DesiredStatus" json:"desiredStatus,omitempty"`
+	// modify index sequence number
+	Sequence   int64  `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	InputState []byte `protobuf:"bytes,9,opt,name=inputState,proto3" json:"inputState,omitempty"`
+	Alias      string `protobuf:"bytes,10,opt,name=alias,proto3" json:"alias,omitempty"`
+}
+
+func (x *Allocation) Reset() {
+	*x = Allocation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Allocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Allocation) ProtoMessage() {}
+
+func (x *Allocation) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Allocation.ProtoReflect.Descriptor instead.
+func (*Allocation) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *Allocation) GetId() string {
 	if x != nil {
-		return x.Chain
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ApplyRequest) GetAlias() string {
+func (x *Allocation) GetNodeId() string {
 	if x != nil {
-		return x.Alias
+		return x.NodeId
 	}
 	return ""
 }
 
-func (x *ApplyRequest) GetLogLevel() string {
+func (x *Allocation) GetTasks() map[string]*Task {
 	if x != nil {
-		return x.LogLevel
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *Allocation) GetTaskStates() map[string]*TaskState {
+	if x != nil {
+		return x.TaskStates
+	}
+	return nil
+}
+
+func (x *Allocation) GetStatus() Allocation_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Allocation_Pending
+}
+
+func (x *Allocation) GetSyncStatus() map[string]*Allocation_SyncStatus {
+	if x != nil {
+		return x.SyncStatus
+	}
+	return nil
+}
+
+func (x *Allocation) GetDesiredStatus() Allocation_DesiredStatus {
+	if x != nil {
+		return x.DesiredStatus
+	}
+	return Allocation_Run
+}
+
+func (x *Allocation) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Allocation) GetInputState() []byte {
+	if x != nil {
+		return x.InputState
+	}
+	return nil
+}
+
+func (x *Allocation) GetAlias() string {
+	if x != nil {
+		return x.Alias
 	}
 	return ""
 }
 
-type ApplyResponse struct {
+type TaskState struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	State    TaskState_State `protobuf:"varint,1,opt,name=state,proto3,enum=proto.TaskState_State" json:"state,omitempty"`
+	Failed   bool            `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
+	Restarts uint64          `protobuf:"varint,3,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	Id       string          `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
+	Killing  bool            `protobuf:"varint,7,opt,name=killing,proto3" json:"killing,omitempty"`
 }
 
-func (x *ApplyResponse) Reset() {
-	*x = ApplyResponse{}
+func (x *TaskState) Reset() {
+	*x = TaskState{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[11]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ApplyResponse) String() string {
+func (x *TaskState) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApplyResponse) ProtoMessage() {}
+func (*TaskState) ProtoMessage() {}
 
-func (x *ApplyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[11]
+func (x *TaskState) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -751,46 +2822,81 @@ func (x *ApplyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApplyResponse.ProtoReflect.Descriptor instead.
-func (*ApplyResponse) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use TaskState.ProtoReflect.Descriptor instead.
+func (*TaskState) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *ApplyResponse) GetId() string {
+func (x *TaskState) GetState() TaskState_State {
+	if x != nil {
+		return x.State
+	}
+	return TaskState_Pending
+}
+
+func (x *TaskState) GetFailed() bool {
+	if x != nil {
+		return x.Failed
+	}
+	return false
+}
+
+func (x *TaskState) GetRestarts() uint64 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+func (x *TaskState) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-// Provisonal name, item is an item on the catalog
-type Item struct {
+func (x *TaskState) GetKilling() bool {
+	if x != nil {
+		return x.Killing
+	}
+	return false
+}
+
+type Deployment2 struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name   string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Fields []*Item_Field `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
-	Chains []string      `protobuf:"bytes,3,rep,name=chains,proto3" json:"chains,omitempty"`
+	Id     string             `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name   string             `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Spec   []byte             `protobuf:"bytes,3,opt,name=spec,proto3" json:"spec,omitempty"`
+	Status Deployment2_Status `protobuf:"varint,4,opt,name=status,proto3,enum=proto.Deployment2_Status" json:"status,omitempty"`
+	// name of the plugin of the deployment
+	Action  string `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Chain   string `protobuf:"bytes,6,opt,name=chain,proto3" json:"chain,omitempty"`
+	Metrics bool   `protobuf:"varint,7,opt,name=metrics,proto3" json:"metrics,omitempty"`
+	Alias   string `protobuf:"bytes,8,opt,name=alias,proto3" json:"alias,omitempty"`
+	// reason why the tasks of a Stopped deployment are stopped (i.e. disk)
+	StopReason string `protobuf:"bytes,9,opt,name=stopReason,proto3" json:"stopReason,omitempty"`
 }
 
-func (x *Item) Reset() {
-	*x = Item{}
+func (x *Deployment2) Reset() {
+	*x = Deployment2{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[12]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Item) String() string {
+func (x *Deployment2) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Item) ProtoMessage() {}
+func (*Deployment2) ProtoMessage() {}
 
-func (x *Item) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[12]
+func (x *Deployment2) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -801,60 +2907,109 @@ func (x *Item) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Item.ProtoReflect.Descriptor instead.
-func (*Item) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use Deployment2.ProtoReflect.Descriptor instead.
+func (*Deployment2) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *Item) GetName() string {
+func (x *Deployment2) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Deployment2) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *Item) GetFields() []*Item_Field {
+func (x *Deployment2) GetSpec() []byte {
 	if x != nil {
-		return x.Fields
+		return x.Spec
 	}
 	return nil
 }
 
-func (x *Item) GetChains() []string {
+func (x *Deployment2) GetStatus() Deployment2_Status {
 	if x != nil {
-		return x.Chains
+		return x.Status
 	}
-	return nil
+	return Deployment2_Running
 }
 
-// Node1 is a node that can allocate resources
-type Node struct {
+func (x *Deployment2) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *Deployment2) GetChain() string {
+	if x != nil {
+		return x.Chain
+	}
+	return ""
+}
+
+func (x *Deployment2) GetMetrics() bool {
+	if x != nil {
+		return x.Metrics
+	}
+	return false
+}
+
+func (x *Deployment2) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *Deployment2) GetStopReason() string {
+	if x != nil {
+		return x.StopReason
+	}
+	return ""
+}
+
+// DeploymentRevision is a version of a deployment applied
+type DeploymentRevision struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Datacenter string `protobuf:"bytes,2,opt,name=datacenter,proto3" json:"datacenter,omitempty"`
-	Name       string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Deployment string `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	Revision   uint64 `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	// spec is the state of the deployment
+	Spec []byte `protobuf:"bytes,3,opt,name=spec,proto3" json:"spec,omitempty"`
+	// tasks rendered by the plugin
+	Tasks         map[string]*Task `protobuf:"bytes,4,rep,name=tasks,proto3" json:"tasks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Plugin        string           `protobuf:"bytes,5,opt,name=plugin,proto3" json:"plugin,omitempty"`
+	PluginVersion string           `protobuf:"bytes,6,opt,name=pluginVersion,proto3" json:"pluginVersion,omitempty"`
+	// unix timestamp of the revision
+	CreatedAt int64 `protobuf:"varint,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
 }
 
-func (x *Node) Reset() {
-	*x = Node{}
+func (x *DeploymentRevision) Reset() {
+	*x = DeploymentRevision{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[13]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Node) String() string {
+func (x *DeploymentRevision) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Node) ProtoMessage() {}
+func (*DeploymentRevision) ProtoMessage() {}
 
-func (x *Node) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[13]
+func (x *DeploymentRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -862,72 +3017,99 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentRevision.ProtoReflect.Descriptor instead.
+func (*DeploymentRevision) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeploymentRevision) GetDeployment() string {
+	if x != nil {
+		return x.Deployment
+	}
+	return ""
+}
+
+func (x *DeploymentRevision) GetRevision() uint64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *DeploymentRevision) GetSpec() []byte {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
 }
 
-// Deprecated: Use Node.ProtoReflect.Descriptor instead.
-func (*Node) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{13}
+func (x *DeploymentRevision) GetTasks() map[string]*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
 }
 
-func (x *Node) GetId() string {
+func (x *DeploymentRevision) GetPlugin() string {
 	if x != nil {
-		return x.Id
+		return x.Plugin
 	}
 	return ""
 }
 
-func (x *Node) GetDatacenter() string {
+func (x *DeploymentRevision) GetPluginVersion() string {
 	if x != nil {
-		return x.Datacenter
+		return x.PluginVersion
 	}
 	return ""
 }
 
-func (x *Node) GetName() string {
+func (x *DeploymentRevision) GetCreatedAt() int64 {
 	if x != nil {
-		return x.Name
+		return x.CreatedAt
 	}
-	return ""
+	return 0
 }
 
-// Task represents an single container process
-type Task struct {
+// TaskState2 is the state of a task of a deployment computed
+// from the state of its container
+type TaskState2 struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Image       string            `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
-	Tag         string            `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
-	Args        []string          `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
-	Env         map[string]string `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Labels      map[string]string `protobuf:"bytes,5,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	SecurityOpt []string          `protobuf:"bytes,7,rep,name=securityOpt,proto3" json:"securityOpt,omitempty"`
-	// list of data access for this file
-	Data      map[string]string       `protobuf:"bytes,9,rep,name=data,proto3" json:"data,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Volumes   map[string]*Task_Volume `protobuf:"bytes,11,rep,name=volumes,proto3" json:"volumes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Telemetry *Task_Telemetry         `protobuf:"bytes,12,opt,name=telemetry,proto3" json:"telemetry,omitempty"`
-	Artifacts []*Task_Artifact        `protobuf:"bytes,13,rep,name=artifacts,proto3" json:"artifacts,omitempty"`
-	Batch     bool                    `protobuf:"varint,14,opt,name=batch,proto3" json:"batch,omitempty"`
+	Name     string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State    TaskState2_State `protobuf:"varint,2,opt,name=state,proto3,enum=proto.TaskState2_State" json:"state,omitempty"`
+	ExitCode int32            `protobuf:"varint,3,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	// error is the last error reported by the runtime
+	Error string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	// restarts is the number of times the task has been restarted
+	Restarts uint64 `protobuf:"varint,5,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	// startedAt and finishedAt are unix timestamps
+	StartedAt  int64 `protobuf:"varint,6,opt,name=startedAt,proto3" json:"startedAt,omitempty"`
+	FinishedAt int64 `protobuf:"varint,7,opt,name=finishedAt,proto3" json:"finishedAt,omitempty"`
 }
 
-func (x *Task) Reset() {
-	*x = Task{}
+func (x *TaskState2) Reset() {
+	*x = TaskState2{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[14]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Task) String() string {
+func (x *TaskState2) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Task) ProtoMessage() {}
+func (*TaskState2) ProtoMessage() {}
 
-func (x *Task) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[14]
+func (x *TaskState2) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -938,130 +3120,98 @@ func (x *Task) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Task.ProtoReflect.Descriptor instead.
-func (*Task) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{14}
-}
-
-func (x *Task) GetImage() string {
-	if x != nil {
-		return x.Image
-	}
-	return ""
+// Deprecated: Use TaskState2.ProtoReflect.Descriptor instead.
+func (*TaskState2) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *Task) GetTag() string {
+func (x *TaskState2) GetName() string {
 	if x != nil {
-		return x.Tag
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Task) GetArgs() []string {
-	if x != nil {
-		return x.Args
-	}
-	return nil
-}
-
-func (x *Task) GetEnv() map[string]string {
-	if x != nil {
-		return x.Env
-	}
-	return nil
-}
-
-func (x *Task) GetLabels() map[string]string {
-	if x != nil {
-		return x.Labels
-	}
-	return nil
-}
-
-func (x *Task) GetSecurityOpt() []string {
+func (x *TaskState2) GetState() TaskState2_State {
 	if x != nil {
-		return x.SecurityOpt
+		return x.State
 	}
-	return nil
+	return TaskState2_Pending
 }
 
-func (x *Task) GetData() map[string]string {
+func (x *TaskState2) GetExitCode() int32 {
 	if x != nil {
-		return x.Data
+		return x.ExitCode
 	}
-	return nil
+	return 0
 }
 
-func (x *Task) GetVolumes() map[string]*Task_Volume {
+func (x *TaskState2) GetError() string {
 	if x != nil {
-		return x.Volumes
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-func (x *Task) GetTelemetry() *Task_Telemetry {
+func (x *TaskState2) GetRestarts() uint64 {
 	if x != nil {
-		return x.Telemetry
+		return x.Restarts
 	}
-	return nil
+	return 0
 }
 
-func (x *Task) GetArtifacts() []*Task_Artifact {
+func (x *TaskState2) GetStartedAt() int64 {
 	if x != nil {
-		return x.Artifacts
+		return x.StartedAt
 	}
-	return nil
+	return 0
 }
 
-func (x *Task) GetBatch() bool {
+func (x *TaskState2) GetFinishedAt() int64 {
 	if x != nil {
-		return x.Batch
+		return x.FinishedAt
 	}
-	return false
+	return 0
 }
 
-// Allocation represents an allocation of a deployment
-type Allocation struct {
+type Event2 struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// id of the allocation
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// node to which the allocation is assigned
-	NodeId string `protobuf:"bytes,2,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
-	// tasks to run on the allocation
-	Tasks map[string]*Task `protobuf:"bytes,3,rep,name=tasks,proto3" json:"tasks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// state of each of the tasks
-	TaskStates map[string]*TaskState `protobuf:"bytes,4,rep,name=taskStates,proto3" json:"taskStates,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// status of the allocation
-	Status     Allocation_Status                 `protobuf:"varint,5,opt,name=status,proto3,enum=proto.Allocation_Status" json:"status,omitempty"`
-	SyncStatus map[string]*Allocation_SyncStatus `protobuf:"bytes,6,rep,name=syncStatus,proto3" json:"syncStatus,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// desired status of the allocation
-	DesiredStatus Allocation_DesiredStatus `protobuf:"varint,7,opt,name=desiredStatus,proto3,enum=proto.Allocation_DesiredStatus" json:"desiredStatus,omitempty"`
-	// modify index sequence number
-	Sequence   int64  `protobuf:"varint,8,opt,name=sequence,proto3" json:"sequence,omitempty"`
-	InputState []byte `protobuf:"bytes,9,opt,name=inputState,proto3" json:"inputState,omitempty"`
-	Alias      string `protobuf:"bytes,10,opt,name=alias,proto3" json:"alias,omitempty"`
+	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Task       string `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	Deployment string `protobuf:"bytes,3,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	Type       string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// index is the position of the event in the event log
+	Index uint64 `protobuf:"varint,5,opt,name=index,proto3" json:"index,omitempty"`
+	// timestamp is the unix time of the event in nanoseconds
+	Timestamp int64           `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Severity  Event2_Severity `protobuf:"varint,7,opt,name=severity,proto3,enum=proto.Event2_Severity" json:"severity,omitempty"`
+	// message is a human readable description of the event
+	Message string `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`
+	// attributes are the attributes reported by the runtime
+	// (i.e. exitCode, image, signal)
+	Attributes map[string]string `protobuf:"bytes,9,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *Allocation) Reset() {
-	*x = Allocation{}
+func (x *Event2) Reset() {
+	*x = Event2{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[15]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Allocation) String() string {
+func (x *Event2) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Allocation) ProtoMessage() {}
+func (*Event2) ProtoMessage() {}
 
-func (x *Allocation) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[15]
+func (x *Event2) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1072,110 +3222,107 @@ func (x *Allocation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Allocation.ProtoReflect.Descriptor instead.
-func (*Allocation) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use Event2.ProtoReflect.Descriptor instead.
+func (*Event2) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *Allocation) GetId() string {
+func (x *Event2) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Allocation) GetNodeId() string {
+func (x *Event2) GetTask() string {
 	if x != nil {
-		return x.NodeId
+		return x.Task
 	}
 	return ""
 }
 
-func (x *Allocation) GetTasks() map[string]*Task {
+func (x *Event2) GetDeployment() string {
 	if x != nil {
-		return x.Tasks
+		return x.Deployment
 	}
-	return nil
+	return ""
 }
 
-func (x *Allocation) GetTaskStates() map[string]*TaskState {
+func (x *Event2) GetType() string {
 	if x != nil {
-		return x.TaskStates
+		return x.Type
 	}
-	return nil
+	return ""
 }
 
-func (x *Allocation) GetStatus() Allocation_Status {
+func (x *Event2) GetIndex() uint64 {
 	if x != nil {
-		return x.Status
+		return x.Index
 	}
-	return Allocation_Pending
+	return 0
 }
 
-func (x *Allocation) GetSyncStatus() map[string]*Allocation_SyncStatus {
+func (x *Event2) GetTimestamp() int64 {
 	if x != nil {
-		return x.SyncStatus
+		return x.Timestamp
 	}
-	return nil
+	return 0
 }
 
-func (x *Allocation) GetDesiredStatus() Allocation_DesiredStatus {
+func (x *Event2) GetSeverity() Event2_Severity {
 	if x != nil {
-		return x.DesiredStatus
+		return x.Severity
 	}
-	return Allocation_Run
+	return Event2_Info
 }
 
-func (x *Allocation) GetSequence() int64 {
+func (x *Event2) GetMessage() string {
 	if x != nil {
-		return x.Sequence
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *Allocation) GetInputState() []byte {
+func (x *Event2) GetAttributes() map[string]string {
 	if x != nil {
-		return x.InputState
+		return x.Attributes
 	}
 	return nil
 }
 
-func (x *Allocation) GetAlias() string {
-	if x != nil {
-		return x.Alias
-	}
-	return ""
-}
-
-type TaskState struct {
+type TaskExecRequest_Setup struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	State    TaskState_State `protobuf:"varint,1,opt,name=state,proto3,enum=proto.TaskState_State" json:"state,omitempty"`
-	Failed   bool            `protobuf:"varint,2,opt,name=failed,proto3" json:"failed,omitempty"`
-	Restarts uint64          `protobuf:"varint,3,opt,name=restarts,proto3" json:"restarts,omitempty"`
-	Id       string          `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
-	Killing  bool            `protobuf:"varint,7,opt,name=killing,proto3" json:"killing,omitempty"`
+	// id (or alias) of the deployment
+	Deployment string `protobuf:"bytes,1,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	// name of the task
+	Task string   `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	Cmd  []string `protobuf:"bytes,3,rep,name=cmd,proto3" json:"cmd,omitempty"`
+	// allocate a terminal for the command
+	Tty bool `protobuf:"varint,4,opt,name=tty,proto3" json:"tty,omitempty"`
+	// initial size of the terminal
+	Size *TaskExecRequest_TerminalSize `protobuf:"bytes,5,opt,name=size,proto3" json:"size,omitempty"`
 }
 
-func (x *TaskState) Reset() {
-	*x = TaskState{}
+func (x *TaskExecRequest_Setup) Reset() {
+	*x = TaskExecRequest_Setup{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[16]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TaskState) String() string {
+func (x *TaskExecRequest_Setup) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskState) ProtoMessage() {}
+func (*TaskExecRequest_Setup) ProtoMessage() {}
 
-func (x *TaskState) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[16]
+func (x *TaskExecRequest_Setup) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1186,73 +3333,72 @@ func (x *TaskState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskState.ProtoReflect.Descriptor instead.
-func (*TaskState) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use TaskExecRequest_Setup.ProtoReflect.Descriptor instead.
+func (*TaskExecRequest_Setup) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{23, 0}
 }
 
-func (x *TaskState) GetState() TaskState_State {
+func (x *TaskExecRequest_Setup) GetDeployment() string {
 	if x != nil {
-		return x.State
+		return x.Deployment
 	}
-	return TaskState_Pending
+	return ""
 }
 
-func (x *TaskState) GetFailed() bool {
+func (x *TaskExecRequest_Setup) GetTask() string {
 	if x != nil {
-		return x.Failed
+		return x.Task
 	}
-	return false
+	return ""
 }
 
-func (x *TaskState) GetRestarts() uint64 {
+func (x *TaskExecRequest_Setup) GetCmd() []string {
 	if x != nil {
-		return x.Restarts
+		return x.Cmd
 	}
-	return 0
+	return nil
 }
 
-func (x *TaskState) GetId() string {
+func (x *TaskExecRequest_Setup) GetTty() bool {
 	if x != nil {
-		return x.Id
+		return x.Tty
 	}
-	return ""
+	return false
 }
 
-func (x *TaskState) GetKilling() bool {
+func (x *TaskExecRequest_Setup) GetSize() *TaskExecRequest_TerminalSize {
 	if x != nil {
-		return x.Killing
+		return x.Size
 	}
-	return false
+	return nil
 }
 
-type Deployment2 struct {
+type TaskExecRequest_TerminalSize struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Spec []byte `protobuf:"bytes,3,opt,name=spec,proto3" json:"spec,omitempty"`
+	Height uint32 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Width  uint32 `protobuf:"varint,2,opt,name=width,proto3" json:"width,omitempty"`
 }
 
-func (x *Deployment2) Reset() {
-	*x = Deployment2{}
+func (x *TaskExecRequest_TerminalSize) Reset() {
+	*x = TaskExecRequest_TerminalSize{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[17]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Deployment2) String() string {
+func (x *TaskExecRequest_TerminalSize) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Deployment2) ProtoMessage() {}
+func (*TaskExecRequest_TerminalSize) ProtoMessage() {}
 
-func (x *Deployment2) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[17]
+func (x *TaskExecRequest_TerminalSize) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1263,60 +3409,53 @@ func (x *Deployment2) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Deployment2.ProtoReflect.Descriptor instead.
-func (*Deployment2) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{17}
-}
-
-func (x *Deployment2) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use TaskExecRequest_TerminalSize.ProtoReflect.Descriptor instead.
+func (*TaskExecRequest_TerminalSize) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{23, 1}
 }
 
-func (x *Deployment2) GetName() string {
+func (x *TaskExecRequest_TerminalSize) GetHeight() uint32 {
 	if x != nil {
-		return x.Name
+		return x.Height
 	}
-	return ""
+	return 0
 }
 
-func (x *Deployment2) GetSpec() []byte {
+func (x *TaskExecRequest_TerminalSize) GetWidth() uint32 {
 	if x != nil {
-		return x.Spec
+		return x.Width
 	}
-	return nil
+	return 0
 }
 
-type Event2 struct {
+type Plan_FieldDiff struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id         string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Task       string `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
-	Deployment string `protobuf:"bytes,3,opt,name=deployment,proto3" json:"deployment,omitempty"`
-	Type       string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Name     string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Old      string `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"`
+	New      string `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"`
+	ForceNew bool   `protobuf:"varint,4,opt,name=forceNew,proto3" json:"forceNew,omitempty"`
 }
 
-func (x *Event2) Reset() {
-	*x = Event2{}
+func (x *Plan_FieldDiff) Reset() {
+	*x = Plan_FieldDiff{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[18]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Event2) String() string {
+func (x *Plan_FieldDiff) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Event2) ProtoMessage() {}
+func (*Plan_FieldDiff) ProtoMessage() {}
 
-func (x *Event2) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[18]
+func (x *Plan_FieldDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1327,37 +3466,37 @@ func (x *Event2) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Event2.ProtoReflect.Descriptor instead.
-func (*Event2) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use Plan_FieldDiff.ProtoReflect.Descriptor instead.
+func (*Plan_FieldDiff) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{29, 1}
 }
 
-func (x *Event2) GetId() string {
+func (x *Plan_FieldDiff) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Event2) GetTask() string {
+func (x *Plan_FieldDiff) GetOld() string {
 	if x != nil {
-		return x.Task
+		return x.Old
 	}
 	return ""
 }
 
-func (x *Event2) GetDeployment() string {
+func (x *Plan_FieldDiff) GetNew() string {
 	if x != nil {
-		return x.Deployment
+		return x.New
 	}
 	return ""
 }
 
-func (x *Event2) GetType() string {
+func (x *Plan_FieldDiff) GetForceNew() bool {
 	if x != nil {
-		return x.Type
+		return x.ForceNew
 	}
-	return ""
+	return false
 }
 
 type Item_Field struct {
@@ -1375,7 +3514,7 @@ type Item_Field struct {
 func (x *Item_Field) Reset() {
 	*x = Item_Field{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[19]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1388,7 +3527,7 @@ func (x *Item_Field) String() string {
 func (*Item_Field) ProtoMessage() {}
 
 func (x *Item_Field) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[19]
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1401,7 +3540,7 @@ func (x *Item_Field) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Item_Field.ProtoReflect.Descriptor instead.
 func (*Item_Field) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{12, 0}
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{31, 1}
 }
 
 func (x *Item_Field) GetName() string {
@@ -1450,7 +3589,7 @@ type Task_Volume struct {
 func (x *Task_Volume) Reset() {
 	*x = Task_Volume{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[24]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1463,7 +3602,7 @@ func (x *Task_Volume) String() string {
 func (*Task_Volume) ProtoMessage() {}
 
 func (x *Task_Volume) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[24]
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1476,7 +3615,7 @@ func (x *Task_Volume) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Task_Volume.ProtoReflect.Descriptor instead.
 func (*Task_Volume) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{14, 4}
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{33, 4}
 }
 
 func (x *Task_Volume) GetPath() string {
@@ -1498,7 +3637,7 @@ type Task_Telemetry struct {
 func (x *Task_Telemetry) Reset() {
 	*x = Task_Telemetry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[25]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1511,7 +3650,7 @@ func (x *Task_Telemetry) String() string {
 func (*Task_Telemetry) ProtoMessage() {}
 
 func (x *Task_Telemetry) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[25]
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1524,7 +3663,7 @@ func (x *Task_Telemetry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Task_Telemetry.ProtoReflect.Descriptor instead.
 func (*Task_Telemetry) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{14, 5}
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{33, 5}
 }
 
 func (x *Task_Telemetry) GetPort() uint64 {
@@ -1548,12 +3687,20 @@ type Task_Artifact struct {
 
 	Source      string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
 	Destination string `protobuf:"bytes,2,opt,name=destination,proto3" json:"destination,omitempty"`
+	// sha256 digest (hex) of the artifact. It is verified
+	// after the download if set.
+	Sha256 string `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	// source of the minisign signature of the artifact. It is
+	// verified with the publicKey after the download if set.
+	Signature string `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	// minisign public key that signed the artifact
+	PublicKey string `protobuf:"bytes,5,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
 }
 
 func (x *Task_Artifact) Reset() {
 	*x = Task_Artifact{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[26]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1566,7 +3713,7 @@ func (x *Task_Artifact) String() string {
 func (*Task_Artifact) ProtoMessage() {}
 
 func (x *Task_Artifact) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[26]
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1579,7 +3726,7 @@ func (x *Task_Artifact) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Task_Artifact.ProtoReflect.Descriptor instead.
 func (*Task_Artifact) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{14, 6}
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{33, 6}
 }
 
 func (x *Task_Artifact) GetSource() string {
@@ -1596,6 +3743,27 @@ func (x *Task_Artifact) GetDestination() string {
 	return ""
 }
 
+func (x *Task_Artifact) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Task_Artifact) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *Task_Artifact) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
 type Allocation_SyncStatus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1610,7 +3778,7 @@ type Allocation_SyncStatus struct {
 func (x *Allocation_SyncStatus) Reset() {
 	*x = Allocation_SyncStatus{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_server_proto_vesta_proto_msgTypes[30]
+		mi := &file_internal_server_proto_vesta_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1623,384 +3791,911 @@ func (x *Allocation_SyncStatus) String() string {
 func (*Allocation_SyncStatus) ProtoMessage() {}
 
 func (x *Allocation_SyncStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_server_proto_vesta_proto_msgTypes[30]
+	mi := &file_internal_server_proto_vesta_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Allocation_SyncStatus.ProtoReflect.Descriptor instead.
-func (*Allocation_SyncStatus) Descriptor() ([]byte, []int) {
-	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{15, 3}
-}
-
-func (x *Allocation_SyncStatus) GetIsSynced() bool {
-	if x != nil {
-		return x.IsSynced
-	}
-	return false
-}
-
-func (x *Allocation_SyncStatus) GetCurrentBlock() uint64 {
-	if x != nil {
-		return x.CurrentBlock
-	}
-	return 0
-}
-
-func (x *Allocation_SyncStatus) GetHighestBlock() uint64 {
-	if x != nil {
-		return x.HighestBlock
-	}
-	return 0
-}
-
-func (x *Allocation_SyncStatus) GetNumPeers() uint64 {
-	if x != nil {
-		return x.NumPeers
-	}
-	return 0
-}
-
-var File_internal_server_proto_vesta_proto protoreflect.FileDescriptor
-
-var file_internal_server_proto_vesta_proto_rawDesc = []byte{
-	0x0a, 0x21, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65,
-	0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x65, 0x73, 0x74, 0x61, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x61,
-	0x74, 0x61, 0x6c, 0x6f, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x22, 0x2f, 0x0a, 0x13, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6c, 0x75, 0x67, 0x69,
-	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
-	0x73, 0x22, 0x2b, 0x0a, 0x15, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x73, 0x70,
-	0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x39,
-	0x0a, 0x16, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49,
-	0x74, 0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x22, 0x20, 0x0a, 0x0e, 0x44, 0x65, 0x73,
-	0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x11, 0x0a, 0x0f, 0x44,
-	0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x29,
-	0x0a, 0x17, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x75, 0x0a, 0x18, 0x44, 0x65, 0x70,
-	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x0a, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x32, 0x52, 0x0a, 0x61,
-	0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x06, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x32, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
-	0x22, 0x17, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4e, 0x0a, 0x16, 0x4c, 0x69, 0x73,
-	0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x0b, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x32, 0x52, 0x0b, 0x61, 0x6c,
-	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xc2, 0x01, 0x0a, 0x0c, 0x41, 0x70,
-	0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x6c, 0x6c, 0x6f,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
-	0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07,
-	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6d,
-	0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05,
-	0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69,
-	0x61, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x07,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x1f,
-	0x0a, 0x0d, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
-	0xe7, 0x01, 0x0a, 0x04, 0x49, 0x74, 0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x06,
-	0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x74, 0x65, 0x6d, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52,
-	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x1a,
-	0x87, 0x01, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x1a, 0x0a,
-	0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x22, 0x4a, 0x0a, 0x04, 0x4e, 0x6f, 0x64,
-	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x63, 0x65, 0x6e, 0x74, 0x65,
-	0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xb0, 0x06, 0x0a, 0x04, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x14,
-	0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69,
-	0x6d, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x26, 0x0a, 0x03, 0x65, 0x6e,
-	0x76, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x54, 0x61, 0x73, 0x6b, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65,
-	0x6e, 0x76, 0x12, 0x2f, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e,
-	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62,
-	0x65, 0x6c, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4f,
-	0x70, 0x74, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69,
-	0x74, 0x79, 0x4f, 0x70, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b,
-	0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x12, 0x32, 0x0a, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x56,
-	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x76, 0x6f, 0x6c,
-	0x75, 0x6d, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x09, 0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72,
-	0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x54, 0x61, 0x73, 0x6b, 0x2e, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x09,
-	0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x12, 0x32, 0x0a, 0x09, 0x61, 0x72, 0x74,
-	0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
-	0x63, 0x74, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x62, 0x61,
-	0x74, 0x63, 0x68, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x4c,
-	0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x37, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e,
-	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
-	0x4e, 0x0a, 0x0c, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x28, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x56, 0x6f,
-	0x6c, 0x75, 0x6d, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
-	0x1c, 0x0a, 0x06, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74,
-	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x1a, 0x33, 0x0a,
-	0x09, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f,
-	0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12,
-	0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61,
-	0x74, 0x68, 0x1a, 0x44, 0x0a, 0x08, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x16,
-	0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
-	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9f, 0x07, 0x0a, 0x0a, 0x41, 0x6c, 0x6c,
-	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12,
-	0x32, 0x0a, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x74, 0x61,
-	0x73, 0x6b, 0x73, 0x12, 0x41, 0x0a, 0x0a, 0x74, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65,
-	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53,
-	0x74, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x74, 0x61, 0x73, 0x6b,
-	0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41,
-	0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x41, 0x0a, 0x0a, 0x73, 0x79, 0x6e, 0x63,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
-	0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x0a, 0x73, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x45, 0x0a, 0x0d, 0x64,
-	0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x08,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1e,
-	0x0a, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61,
-	0x6c, 0x69, 0x61, 0x73, 0x1a, 0x45, 0x0a, 0x0a, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4f, 0x0a, 0x0f, 0x54,
-	0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
-	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
-	0x12, 0x26, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74,
-	0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5b, 0x0a, 0x0f,
-	0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x32, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x8c, 0x01, 0x0a, 0x0a, 0x53, 0x79,
-	0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x73, 0x53, 0x79,
-	0x6e, 0x63, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x53, 0x79,
-	0x6e, 0x63, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42,
-	0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x68, 0x69, 0x67, 0x68,
-	0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
-	0x68, 0x69, 0x67, 0x68, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08,
-	0x6e, 0x75, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
-	0x6e, 0x75, 0x6d, 0x50, 0x65, 0x65, 0x72, 0x73, 0x22, 0x3c, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x10, 0x00, 0x12,
-	0x0b, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08,
-	0x43, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x61,
-	0x69, 0x6c, 0x65, 0x64, 0x10, 0x03, 0x22, 0x22, 0x0a, 0x0d, 0x44, 0x65, 0x73, 0x69, 0x72, 0x65,
-	0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x75, 0x6e, 0x10, 0x00,
-	0x12, 0x08, 0x0a, 0x04, 0x53, 0x74, 0x6f, 0x70, 0x10, 0x01, 0x22, 0xc4, 0x01, 0x0a, 0x09, 0x54,
-	0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52,
-	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x1a,
-	0x0a, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x69,
-	0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6b, 0x69, 0x6c,
-	0x6c, 0x69, 0x6e, 0x67, 0x22, 0x2b, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a,
-	0x07, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x75,
-	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x65, 0x61, 0x64, 0x10,
-	0x02, 0x22, 0x45, 0x0a, 0x0b, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x32,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0x60, 0x0a, 0x06, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x32, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79,
-	0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c,
-	0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x32, 0xb5, 0x03, 0x0a, 0x0c, 0x56,
-	0x65, 0x73, 0x74, 0x61, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x32, 0x0a, 0x05, 0x41,
-	0x70, 0x70, 0x6c, 0x79, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x70, 0x70,
-	0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x38, 0x0a, 0x07, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x12, 0x15, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f,
-	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x44, 0x65, 0x70,
-	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65,
-	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x10, 0x44, 0x65, 0x70, 0x6c,
-	0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a,
-	0x0b, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x4c, 0x69, 0x73, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
-	0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x6e,
-	0x73, 0x70, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61,
-	0x74, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x74, 0x61,
-	0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x42, 0x18, 0x5a, 0x16, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
-	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_internal_server_proto_vesta_proto_rawDescOnce sync.Once
-	file_internal_server_proto_vesta_proto_rawDescData = file_internal_server_proto_vesta_proto_rawDesc
-)
-
-func file_internal_server_proto_vesta_proto_rawDescGZIP() []byte {
-	file_internal_server_proto_vesta_proto_rawDescOnce.Do(func() {
-		file_internal_server_proto_vesta_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_server_proto_vesta_proto_rawDescData)
-	})
-	return file_internal_server_proto_vesta_proto_rawDescData
-}
-
-var file_internal_server_proto_vesta_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_internal_server_proto_vesta_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
-var file_internal_server_proto_vesta_proto_goTypes = []interface{}{
-	(Allocation_Status)(0),           // 0: proto.Allocation.Status
-	(Allocation_DesiredStatus)(0),    // 1: proto.Allocation.DesiredStatus
-	(TaskState_State)(0),             // 2: proto.TaskState.State
-	(*CatalogListRequest)(nil),       // 3: proto.CatalogListRequest
-	(*CatalogListResponse)(nil),      // 4: proto.CatalogListResponse
-	(*CatalogInspectRequest)(nil),    // 5: proto.CatalogInspectRequest
-	(*CatalogInspectResponse)(nil),   // 6: proto.CatalogInspectResponse
-	(*DestroyRequest)(nil),           // 7: proto.DestroyRequest
-	(*DestroyResponse)(nil),          // 8: proto.DestroyResponse
-	(*DeploymentStatusRequest)(nil),  // 9: proto.DeploymentStatusRequest
-	(*DeploymentStatusResponse)(nil), // 10: proto.DeploymentStatusResponse
-	(*ListDeploymentRequest)(nil),    // 11: proto.ListDeploymentRequest
-	(*ListDeploymentResponse)(nil),   // 12: proto.ListDeploymentResponse
-	(*ApplyRequest)(nil),             // 13: proto.ApplyRequest
-	(*ApplyResponse)(nil),            // 14: proto.ApplyResponse
-	(*Item)(nil),                     // 15: proto.Item
-	(*Node)(nil),                     // 16: proto.Node
-	(*Task)(nil),                     // 17: proto.Task
-	(*Allocation)(nil),               // 18: proto.Allocation
-	(*TaskState)(nil),                // 19: proto.TaskState
-	(*Deployment2)(nil),              // 20: proto.Deployment2
-	(*Event2)(nil),                   // 21: proto.Event2
-	(*Item_Field)(nil),               // 22: proto.Item.Field
-	nil,                              // 23: proto.Task.EnvEntry
-	nil,                              // 24: proto.Task.LabelsEntry
-	nil,                              // 25: proto.Task.DataEntry
-	nil,                              // 26: proto.Task.VolumesEntry
-	(*Task_Volume)(nil),              // 27: proto.Task.Volume
-	(*Task_Telemetry)(nil),           // 28: proto.Task.Telemetry
-	(*Task_Artifact)(nil),            // 29: proto.Task.Artifact
-	nil,                              // 30: proto.Allocation.TasksEntry
-	nil,                              // 31: proto.Allocation.TaskStatesEntry
-	nil,                              // 32: proto.Allocation.SyncStatusEntry
-	(*Allocation_SyncStatus)(nil),    // 33: proto.Allocation.SyncStatus
-}
-var file_internal_server_proto_vesta_proto_depIdxs = []int32{
-	15, // 0: proto.CatalogInspectResponse.item:type_name -> proto.Item
-	20, // 1: proto.DeploymentStatusResponse.allocation:type_name -> proto.Deployment2
-	21, // 2: proto.DeploymentStatusResponse.events:type_name -> proto.Event2
-	20, // 3: proto.ListDeploymentResponse.allocations:type_name -> proto.Deployment2
-	22, // 4: proto.Item.fields:type_name -> proto.Item.Field
-	23, // 5: proto.Task.env:type_name -> proto.Task.EnvEntry
-	24, // 6: proto.Task.labels:type_name -> proto.Task.LabelsEntry
-	25, // 7: proto.Task.data:type_name -> proto.Task.DataEntry
-	26, // 8: proto.Task.volumes:type_name -> proto.Task.VolumesEntry
-	28, // 9: proto.Task.telemetry:type_name -> proto.Task.Telemetry
-	29, // 10: proto.Task.artifacts:type_name -> proto.Task.Artifact
-	30, // 11: proto.Allocation.tasks:type_name -> proto.Allocation.TasksEntry
-	31, // 12: proto.Allocation.taskStates:type_name -> proto.Allocation.TaskStatesEntry
-	0,  // 13: proto.Allocation.status:type_name -> proto.Allocation.Status
-	32, // 14: proto.Allocation.syncStatus:type_name -> proto.Allocation.SyncStatusEntry
-	1,  // 15: proto.Allocation.desiredStatus:type_name -> proto.Allocation.DesiredStatus
-	2,  // 16: proto.TaskState.state:type_name -> proto.TaskState.State
-	27, // 17: proto.Task.VolumesEntry.value:type_name -> proto.Task.Volume
-	17, // 18: proto.Allocation.TasksEntry.value:type_name -> proto.Task
-	19, // 19: proto.Allocation.TaskStatesEntry.value:type_name -> proto.TaskState
-	33, // 20: proto.Allocation.SyncStatusEntry.value:type_name -> proto.Allocation.SyncStatus
-	13, // 21: proto.VestaService.Apply:input_type -> proto.ApplyRequest
-	7,  // 22: proto.VestaService.Destroy:input_type -> proto.DestroyRequest
-	11, // 23: proto.VestaService.DeploymentList:input_type -> proto.ListDeploymentRequest
-	9,  // 24: proto.VestaService.DeploymentStatus:input_type -> proto.DeploymentStatusRequest
-	3,  // 25: proto.VestaService.CatalogList:input_type -> proto.CatalogListRequest
-	5,  // 26: proto.VestaService.CatalogInspect:input_type -> proto.CatalogInspectRequest
-	14, // 27: proto.VestaService.Apply:output_type -> proto.ApplyResponse
-	8,  // 28: proto.VestaService.Destroy:output_type -> proto.DestroyResponse
-	12, // 29: proto.VestaService.DeploymentList:output_type -> proto.ListDeploymentResponse
-	10, // 30: proto.VestaService.DeploymentStatus:output_type -> proto.DeploymentStatusResponse
-	4,  // 31: proto.VestaService.CatalogList:output_type -> proto.CatalogListResponse
-	6,  // 32: proto.VestaService.CatalogInspect:output_type -> proto.CatalogInspectResponse
-	27, // [27:33] is the sub-list for method output_type
-	21, // [21:27] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
-}
-
-func init() { file_internal_server_proto_vesta_proto_init() }
-func file_internal_server_proto_vesta_proto_init() {
-	if File_internal_server_proto_vesta_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_internal_server_proto_vesta_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CatalogListRequest); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Allocation_SyncStatus.ProtoReflect.Descriptor instead.
+func (*Allocation_SyncStatus) Descriptor() ([]byte, []int) {
+	return file_internal_server_proto_vesta_proto_rawDescGZIP(), []int{34, 3}
+}
+
+func (x *Allocation_SyncStatus) GetIsSynced() bool {
+	if x != nil {
+		return x.IsSynced
+	}
+	return false
+}
+
+func (x *Allocation_SyncStatus) GetCurrentBlock() uint64 {
+	if x != nil {
+		return x.CurrentBlock
+	}
+	return 0
+}
+
+func (x *Allocation_SyncStatus) GetHighestBlock() uint64 {
+	if x != nil {
+		return x.HighestBlock
+	}
+	return 0
+}
+
+func (x *Allocation_SyncStatus) GetNumPeers() uint64 {
+	if x != nil {
+		return x.NumPeers
+	}
+	return 0
+}
+
+var File_internal_server_proto_vesta_proto protoreflect.FileDescriptor
+
+var file_internal_server_proto_vesta_proto_rawDesc = []byte{
+	0x0a, 0x21, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x65, 0x73, 0x74, 0x61, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x61,
+	0x74, 0x61, 0x6c, 0x6f, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x2f, 0x0a, 0x13, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x73, 0x22, 0x2b, 0x0a, 0x15, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x39,
+	0x0a, 0x16, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49,
+	0x74, 0x65, 0x6d, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x22, 0x44, 0x0a, 0x0e, 0x44, 0x65, 0x73,
+	0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x70,
+	0x75, 0x72, 0x67, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0c, 0x70, 0x75, 0x72, 0x67, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x22,
+	0x11, 0x0a, 0x0f, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x29, 0x0a, 0x17, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xf6, 0x02,
+	0x0a, 0x18, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x0a, 0x61, 0x6c,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x32, 0x52, 0x0a, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25,
+	0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x32, 0x52, 0x06, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x32, 0x52, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x3e,
+	0x0a, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x1c,
+	0x0a, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x64, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x2a, 0x0a, 0x10,
+	0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x69, 0x7a, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x65, 0x78, 0x70, 0x65, 0x63, 0x74, 0x65, 0x64,
+	0x44, 0x69, 0x73, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x4c, 0x0a, 0x06, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x10, 0x00, 0x12,
+	0x0b, 0x0a, 0x07, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x65, 0x67,
+	0x72, 0x61, 0x64, 0x65, 0x64, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x6e, 0x68, 0x65, 0x61,
+	0x6c, 0x74, 0x68, 0x79, 0x10, 0x04, 0x22, 0x2a, 0x0a, 0x18, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x22, 0x54, 0x0a, 0x19, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x37, 0x0a, 0x09, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x72,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x47, 0x0a, 0x19, 0x44, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x22, 0x5f, 0x0a, 0x1a, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x05, 0x74,
+	0x61, 0x73, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x44, 0x69, 0x66, 0x66, 0x52, 0x05, 0x74, 0x61, 0x73,
+	0x6b, 0x73, 0x22, 0x93, 0x01, 0x0a, 0x15, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b,
+	0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08,
+	0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x22, 0x45, 0x0a, 0x16, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x22,
+	0x7e, 0x0a, 0x14, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x22,
+	0x17, 0x0a, 0x15, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x33, 0x0a, 0x11, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x22, 0x43, 0x0a,
+	0x12, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x73, 0x22, 0xcc, 0x01, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x61, 0x73, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x22, 0x8c, 0x01, 0x0a, 0x12, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x6c,
+	0x6f, 0x77, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77,
+	0x22, 0x3a, 0x0a, 0x13, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x32, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x87, 0x01, 0x0a,
+	0x0f, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x61, 0x73, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x6f, 0x6c, 0x6c, 0x6f, 0x77, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x61, 0x69, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x69, 0x6c,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x22, 0x80, 0x01, 0x0a, 0x10, 0x54, 0x61, 0x73, 0x6b, 0x4c,
+	0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x06, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x20, 0x0a, 0x06, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x10, 0x00, 0x12, 0x0a, 0x0a,
+	0x06, 0x53, 0x74, 0x64, 0x65, 0x72, 0x72, 0x10, 0x01, 0x22, 0x91, 0x03, 0x0a, 0x0f, 0x54, 0x61,
+	0x73, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a,
+	0x05, 0x73, 0x65, 0x74, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x74, 0x75, 0x70, 0x52, 0x05, 0x73, 0x65, 0x74, 0x75,
+	0x70, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x64, 0x69, 0x6e,
+	0x43, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x73, 0x74, 0x64,
+	0x69, 0x6e, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x54, 0x61, 0x73, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x06, 0x72, 0x65,
+	0x73, 0x69, 0x7a, 0x65, 0x1a, 0x98, 0x01, 0x0a, 0x05, 0x53, 0x65, 0x74, 0x75, 0x70, 0x12, 0x1e,
+	0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61,
+	0x73, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x03, 0x63, 0x6d, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x03, 0x74, 0x74, 0x79, 0x12, 0x37, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73,
+	0x6b, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x54, 0x65, 0x72,
+	0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x1a,
+	0x3c, 0x0a, 0x0c, 0x54, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x61, 0x6c, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x22, 0x76, 0x0a,
+	0x10, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f, 0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64,
+	0x65, 0x72, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72,
+	0x72, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x69,
+	0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69,
+	0x74, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x17, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4e,
+	0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x0b, 0x61, 0x6c, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x32, 0x52, 0x0b, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xb7,
+	0x02, 0x0a, 0x0c, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x22, 0x0a,
+	0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x1d, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x08, 0x48, 0x00, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x88, 0x01, 0x01,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x6f, 0x67, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x72, 0x79, 0x52,
+	0x75, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72, 0x79, 0x52, 0x75, 0x6e,
+	0x12, 0x26, 0x0a, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65,
+	0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x66, 0x72, 0x6f, 0x6d, 0x44, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x72, 0x6f, 0x6d,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x66, 0x72, 0x6f, 0x6d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x42, 0x0a, 0x0a, 0x08,
+	0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x22, 0x67, 0x0a, 0x0d, 0x41, 0x70, 0x70, 0x6c,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x25, 0x0a, 0x05, 0x74, 0x61, 0x73,
+	0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x44, 0x69, 0x66, 0x66, 0x52, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73,
+	0x12, 0x1f, 0x0a, 0x04, 0x70, 0x6c, 0x61, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x52, 0x04, 0x70, 0x6c, 0x61,
+	0x6e, 0x22, 0xa5, 0x02, 0x0a, 0x04, 0x50, 0x6c, 0x61, 0x6e, 0x12, 0x2c, 0x0a, 0x05, 0x74, 0x61,
+	0x73, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x2d, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x50, 0x6c, 0x61, 0x6e, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x44, 0x69, 0x66, 0x66, 0x52,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x65,
+	0x64, 0x1a, 0x45, 0x0a, 0x0a, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x21, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5f, 0x0a, 0x09, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x44, 0x69, 0x66, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6f, 0x6c, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6f, 0x6c, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6e,
+	0x65, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6e, 0x65, 0x77, 0x12, 0x1a, 0x0a,
+	0x08, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x4e, 0x65, 0x77, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x4e, 0x65, 0x77, 0x22, 0x9d, 0x01, 0x0a, 0x08, 0x54, 0x61,
+	0x73, 0x6b, 0x44, 0x69, 0x66, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x44, 0x69, 0x66, 0x66, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x3b, 0x0a, 0x04,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x55, 0x6e, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x10, 0x01, 0x12,
+	0x0b, 0x0a, 0x07, 0x52, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07,
+	0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x10, 0x03, 0x22, 0xf5, 0x02, 0x0a, 0x04, 0x49, 0x74,
+	0x65, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49,
+	0x74, 0x65, 0x6d, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x74,
+	0x65, 0x6d, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x1a, 0x3b, 0x0a, 0x0d, 0x44, 0x69,
+	0x73, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x87, 0x01, 0x0a, 0x05, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65,
+	0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x22, 0x4a, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x61, 0x74,
+	0x61, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64,
+	0x61, 0x74, 0x61, 0x63, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xa5, 0x07,
+	0x0a, 0x04, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x10, 0x0a, 0x03,
+	0x74, 0x61, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x12, 0x12,
+	0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72,
+	0x67, 0x73, 0x12, 0x26, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x45, 0x6e, 0x76,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x2f, 0x0a, 0x06, 0x6c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x73,
+	0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4f, 0x70, 0x74, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0b, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x4f, 0x70, 0x74, 0x12, 0x29, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x32, 0x0a, 0x07, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x07, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x09,
+	0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x54, 0x65, 0x6c,
+	0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x09, 0x74, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72,
+	0x79, 0x12, 0x32, 0x0a, 0x09, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x73, 0x18, 0x0d,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73,
+	0x6b, 0x2e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x09, 0x61, 0x72, 0x74, 0x69,
+	0x66, 0x61, 0x63, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x44, 0x61, 0x74, 0x61, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0a, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x36, 0x0a, 0x08, 0x45,
+	0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x37,
+	0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4e, 0x0a, 0x0c, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x1c, 0x0a, 0x06, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x1a, 0x33, 0x0a, 0x09, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74,
+	0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x1a, 0x98, 0x01, 0x0a, 0x08, 0x41,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x4b, 0x65, 0x79, 0x22, 0x9f, 0x07, 0x0a, 0x0a, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64, 0x65, 0x49, 0x64, 0x12, 0x32, 0x0a, 0x05,
+	0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54,
+	0x61, 0x73, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73,
+	0x12, 0x41, 0x0a, 0x0a, 0x74, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x74, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6c, 0x6c, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x41, 0x0a, 0x0a, 0x73, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x79, 0x6e,
+	0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x73, 0x79,
+	0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x45, 0x0a, 0x0d, 0x64, 0x65, 0x73, 0x69,
+	0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x44, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x0d, 0x64, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x1a, 0x0a, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0a, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x61,
+	0x6c, 0x69, 0x61, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x61, 0x6c, 0x69, 0x61,
+	0x73, 0x1a, 0x45, 0x0a, 0x0a, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x21, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4f, 0x0a, 0x0f, 0x54, 0x61, 0x73, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x26, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5b, 0x0a, 0x0f, 0x53, 0x79, 0x6e,
+	0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x32,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x8c, 0x01, 0x0a, 0x0a, 0x53, 0x79, 0x6e, 0x63, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x73, 0x53, 0x79, 0x6e, 0x63, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69, 0x73, 0x53, 0x79, 0x6e, 0x63, 0x65,
+	0x64, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x22, 0x0a, 0x0c, 0x68, 0x69, 0x67, 0x68, 0x65, 0x73, 0x74,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x68, 0x69, 0x67,
+	0x68, 0x65, 0x73, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1a, 0x0a, 0x08, 0x6e, 0x75, 0x6d,
+	0x50, 0x65, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6e, 0x75, 0x6d,
+	0x50, 0x65, 0x65, 0x72, 0x73, 0x22, 0x3c, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
+	0x0b, 0x0a, 0x07, 0x50, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07,
+	0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x43, 0x6f, 0x6d,
+	0x70, 0x6c, 0x65, 0x74, 0x65, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x46, 0x61, 0x69, 0x6c, 0x65,
+	0x64, 0x10, 0x03, 0x22, 0x22, 0x0a, 0x0d, 0x44, 0x65, 0x73, 0x69, 0x72, 0x65, 0x64, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x75, 0x6e, 0x10, 0x00, 0x12, 0x08, 0x0a,
+	0x04, 0x53, 0x74, 0x6f, 0x70, 0x10, 0x01, 0x22, 0xc4, 0x01, 0x0a, 0x09, 0x54, 0x61, 0x73, 0x6b,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x2c, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x69, 0x6c, 0x6c, 0x69,
+	0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6b, 0x69, 0x6c, 0x6c, 0x69, 0x6e,
+	0x67, 0x22, 0x2b, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x65,
+	0x6e, 0x64, 0x69, 0x6e, 0x67, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x6e, 0x69,
+	0x6e, 0x67, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x65, 0x61, 0x64, 0x10, 0x02, 0x22, 0xa9,
+	0x02, 0x0a, 0x0b, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x32, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x32, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x74, 0x6f,
+	0x70, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x31, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x00, 0x12, 0x0d,
+	0x0a, 0x09, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x65, 0x64, 0x10, 0x01, 0x12, 0x0b, 0x0a,
+	0x07, 0x53, 0x74, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x10, 0x02, 0x22, 0xc3, 0x02, 0x0a, 0x12, 0x44,
+	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x70, 0x65,
+	0x63, 0x12, 0x3a, 0x0a, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x24, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x61, 0x73, 0x6b,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x05, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x24, 0x0a, 0x0d, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x6c,
+	0x75, 0x67, 0x69, 0x6e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x1a, 0x45, 0x0a, 0x0a, 0x54, 0x61, 0x73,
+	0x6b, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x21, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0xa7, 0x02, 0x0a, 0x0a, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x32, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x32, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1e,
+	0x0a, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x41, 0x74, 0x22, 0x4a,
+	0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x10,
+	0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x69, 0x6e, 0x67, 0x10,
+	0x02, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x65, 0x61, 0x64, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x43,
+	0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x10, 0x04, 0x22, 0x8e, 0x03, 0x0a, 0x06, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x32, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64,
+	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x12, 0x32, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x32, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76,
+	0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x3d, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x32, 0x2e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x0a, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x1a, 0x3d,
+	0x0a, 0x0f, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x2c, 0x0a,
+	0x08, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x6e, 0x66,
+	0x6f, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x10, 0x01,
+	0x12, 0x09, 0x0a, 0x05, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x10, 0x02, 0x32, 0x8e, 0x08, 0x0a, 0x0c,
+	0x56, 0x65, 0x73, 0x74, 0x61, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x32, 0x0a, 0x05,
+	0x41, 0x70, 0x70, 0x6c, 0x79, 0x12, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x70,
+	0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x41, 0x70, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x38, 0x0a, 0x07, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x12, 0x15, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72, 0x6f, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x73, 0x74, 0x72,
+	0x6f, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x0e, 0x44, 0x65,
+	0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x1c, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d,
+	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x53, 0x0a, 0x10, 0x44, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56,
+	0x0a, 0x11, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74,
+	0x6f, 0x72, 0x79, 0x12, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x12, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79,
+	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x12, 0x20, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x6f, 0x6c, 0x6c, 0x62, 0x61, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x46, 0x0a, 0x0b, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3d, 0x0a, 0x08, 0x54, 0x61, 0x73,
+	0x6b, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61,
+	0x73, 0x6b, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x3f, 0x0a, 0x08, 0x54, 0x61, 0x73, 0x6b,
+	0x45, 0x78, 0x65, 0x63, 0x12, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73,
+	0x6b, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x4d, 0x0a, 0x0e, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1c, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x0d, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x12, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0a, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4c, 0x69,
+	0x73, 0x74, 0x12, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0b, 0x43, 0x61, 0x74, 0x61, 0x6c,
+	0x6f, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f,
+	0x67, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a,
+	0x0e, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x12,
+	0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x49,
+	0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x74, 0x61, 0x6c, 0x6f, 0x67, 0x49, 0x6e, 0x73,
+	0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x18, 0x5a, 0x16,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_internal_server_proto_vesta_proto_rawDescOnce sync.Once
+	file_internal_server_proto_vesta_proto_rawDescData = file_internal_server_proto_vesta_proto_rawDesc
+)
+
+func file_internal_server_proto_vesta_proto_rawDescGZIP() []byte {
+	file_internal_server_proto_vesta_proto_rawDescOnce.Do(func() {
+		file_internal_server_proto_vesta_proto_rawDescData = protoimpl.X.CompressGZIP(file_internal_server_proto_vesta_proto_rawDescData)
+	})
+	return file_internal_server_proto_vesta_proto_rawDescData
+}
+
+var file_internal_server_proto_vesta_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_internal_server_proto_vesta_proto_msgTypes = make([]protoimpl.MessageInfo, 59)
+var file_internal_server_proto_vesta_proto_goTypes = []interface{}{
+	(DeploymentStatusResponse_Health)(0), // 0: proto.DeploymentStatusResponse.Health
+	(TaskLogsResponse_Stream)(0),         // 1: proto.TaskLogsResponse.Stream
+	(TaskDiff_Type)(0),                   // 2: proto.TaskDiff.Type
+	(Allocation_Status)(0),               // 3: proto.Allocation.Status
+	(Allocation_DesiredStatus)(0),        // 4: proto.Allocation.DesiredStatus
+	(TaskState_State)(0),                 // 5: proto.TaskState.State
+	(Deployment2_Status)(0),              // 6: proto.Deployment2.Status
+	(TaskState2_State)(0),                // 7: proto.TaskState2.State
+	(Event2_Severity)(0),                 // 8: proto.Event2.Severity
+	(*CatalogListRequest)(nil),           // 9: proto.CatalogListRequest
+	(*CatalogListResponse)(nil),          // 10: proto.CatalogListResponse
+	(*CatalogInspectRequest)(nil),        // 11: proto.CatalogInspectRequest
+	(*CatalogInspectResponse)(nil),       // 12: proto.CatalogInspectResponse
+	(*DestroyRequest)(nil),               // 13: proto.DestroyRequest
+	(*DestroyResponse)(nil),              // 14: proto.DestroyResponse
+	(*DeploymentStatusRequest)(nil),      // 15: proto.DeploymentStatusRequest
+	(*DeploymentStatusResponse)(nil),     // 16: proto.DeploymentStatusResponse
+	(*DeploymentHistoryRequest)(nil),     // 17: proto.DeploymentHistoryRequest
+	(*DeploymentHistoryResponse)(nil),    // 18: proto.DeploymentHistoryResponse
+	(*DeploymentRollbackRequest)(nil),    // 19: proto.DeploymentRollbackRequest
+	(*DeploymentRollbackResponse)(nil),   // 20: proto.DeploymentRollbackResponse
+	(*VolumeSnapshotRequest)(nil),        // 21: proto.VolumeSnapshotRequest
+	(*VolumeSnapshotResponse)(nil),       // 22: proto.VolumeSnapshotResponse
+	(*VolumeRestoreRequest)(nil),         // 23: proto.VolumeRestoreRequest
+	(*VolumeRestoreResponse)(nil),        // 24: proto.VolumeRestoreResponse
+	(*VolumeListRequest)(nil),            // 25: proto.VolumeListRequest
+	(*VolumeListResponse)(nil),           // 26: proto.VolumeListResponse
+	(*Snapshot)(nil),                     // 27: proto.Snapshot
+	(*WatchEventsRequest)(nil),           // 28: proto.WatchEventsRequest
+	(*WatchEventsResponse)(nil),          // 29: proto.WatchEventsResponse
+	(*TaskLogsRequest)(nil),              // 30: proto.TaskLogsRequest
+	(*TaskLogsResponse)(nil),             // 31: proto.TaskLogsResponse
+	(*TaskExecRequest)(nil),              // 32: proto.TaskExecRequest
+	(*TaskExecResponse)(nil),             // 33: proto.TaskExecResponse
+	(*ListDeploymentRequest)(nil),        // 34: proto.ListDeploymentRequest
+	(*ListDeploymentResponse)(nil),       // 35: proto.ListDeploymentResponse
+	(*ApplyRequest)(nil),                 // 36: proto.ApplyRequest
+	(*ApplyResponse)(nil),                // 37: proto.ApplyResponse
+	(*Plan)(nil),                         // 38: proto.Plan
+	(*TaskDiff)(nil),                     // 39: proto.TaskDiff
+	(*Item)(nil),                         // 40: proto.Item
+	(*Node)(nil),                         // 41: proto.Node
+	(*Task)(nil),                         // 42: proto.Task
+	(*Allocation)(nil),                   // 43: proto.Allocation
+	(*TaskState)(nil),                    // 44: proto.TaskState
+	(*Deployment2)(nil),                  // 45: proto.Deployment2
+	(*DeploymentRevision)(nil),           // 46: proto.DeploymentRevision
+	(*TaskState2)(nil),                   // 47: proto.TaskState2
+	(*Event2)(nil),                       // 48: proto.Event2
+	(*TaskExecRequest_Setup)(nil),        // 49: proto.TaskExecRequest.Setup
+	(*TaskExecRequest_TerminalSize)(nil), // 50: proto.TaskExecRequest.TerminalSize
+	nil,                                  // 51: proto.Plan.TasksEntry
+	(*Plan_FieldDiff)(nil),               // 52: proto.Plan.FieldDiff
+	nil,                                  // 53: proto.Item.DiskSizeEntry
+	(*Item_Field)(nil),                   // 54: proto.Item.Field
+	nil,                                  // 55: proto.Task.EnvEntry
+	nil,                                  // 56: proto.Task.LabelsEntry
+	nil,                                  // 57: proto.Task.DataEntry
+	nil,                                  // 58: proto.Task.VolumesEntry
+	(*Task_Volume)(nil),                  // 59: proto.Task.Volume
+	(*Task_Telemetry)(nil),               // 60: proto.Task.Telemetry
+	(*Task_Artifact)(nil),                // 61: proto.Task.Artifact
+	nil,                                  // 62: proto.Allocation.TasksEntry
+	nil,                                  // 63: proto.Allocation.TaskStatesEntry
+	nil,                                  // 64: proto.Allocation.SyncStatusEntry
+	(*Allocation_SyncStatus)(nil),        // 65: proto.Allocation.SyncStatus
+	nil,                                  // 66: proto.DeploymentRevision.TasksEntry
+	nil,                                  // 67: proto.Event2.AttributesEntry
+}
+var file_internal_server_proto_vesta_proto_depIdxs = []int32{
+	40, // 0: proto.CatalogInspectResponse.item:type_name -> proto.Item
+	45, // 1: proto.DeploymentStatusResponse.allocation:type_name -> proto.Deployment2
+	48, // 2: proto.DeploymentStatusResponse.events:type_name -> proto.Event2
+	47, // 3: proto.DeploymentStatusResponse.tasks:type_name -> proto.TaskState2
+	0,  // 4: proto.DeploymentStatusResponse.health:type_name -> proto.DeploymentStatusResponse.Health
+	46, // 5: proto.DeploymentHistoryResponse.revisions:type_name -> proto.DeploymentRevision
+	39, // 6: proto.DeploymentRollbackResponse.tasks:type_name -> proto.TaskDiff
+	27, // 7: proto.VolumeSnapshotResponse.snapshot:type_name -> proto.Snapshot
+	27, // 8: proto.VolumeListResponse.snapshots:type_name -> proto.Snapshot
+	48, // 9: proto.WatchEventsResponse.event:type_name -> proto.Event2
+	1,  // 10: proto.TaskLogsResponse.stream:type_name -> proto.TaskLogsResponse.Stream
+	49, // 11: proto.TaskExecRequest.setup:type_name -> proto.TaskExecRequest.Setup
+	50, // 12: proto.TaskExecRequest.resize:type_name -> proto.TaskExecRequest.TerminalSize
+	45, // 13: proto.ListDeploymentResponse.allocations:type_name -> proto.Deployment2
+	39, // 14: proto.ApplyResponse.tasks:type_name -> proto.TaskDiff
+	38, // 15: proto.ApplyResponse.plan:type_name -> proto.Plan
+	51, // 16: proto.Plan.tasks:type_name -> proto.Plan.TasksEntry
+	52, // 17: proto.Plan.fields:type_name -> proto.Plan.FieldDiff
+	2,  // 18: proto.TaskDiff.type:type_name -> proto.TaskDiff.Type
+	54, // 19: proto.Item.fields:type_name -> proto.Item.Field
+	53, // 20: proto.Item.diskSize:type_name -> proto.Item.DiskSizeEntry
+	55, // 21: proto.Task.env:type_name -> proto.Task.EnvEntry
+	56, // 22: proto.Task.labels:type_name -> proto.Task.LabelsEntry
+	57, // 23: proto.Task.data:type_name -> proto.Task.DataEntry
+	58, // 24: proto.Task.volumes:type_name -> proto.Task.VolumesEntry
+	60, // 25: proto.Task.telemetry:type_name -> proto.Task.Telemetry
+	61, // 26: proto.Task.artifacts:type_name -> proto.Task.Artifact
+	62, // 27: proto.Allocation.tasks:type_name -> proto.Allocation.TasksEntry
+	63, // 28: proto.Allocation.taskStates:type_name -> proto.Allocation.TaskStatesEntry
+	3,  // 29: proto.Allocation.status:type_name -> proto.Allocation.Status
+	64, // 30: proto.Allocation.syncStatus:type_name -> proto.Allocation.SyncStatusEntry
+	4,  // 31: proto.Allocation.desiredStatus:type_name -> proto.Allocation.DesiredStatus
+	5,  // 32: proto.TaskState.state:type_name -> proto.TaskState.State
+	6,  // 33: proto.Deployment2.status:type_name -> proto.Deployment2.Status
+	66, // 34: proto.DeploymentRevision.tasks:type_name -> proto.DeploymentRevision.TasksEntry
+	7,  // 35: proto.TaskState2.state:type_name -> proto.TaskState2.State
+	8,  // 36: proto.Event2.severity:type_name -> proto.Event2.Severity
+	67, // 37: proto.Event2.attributes:type_name -> proto.Event2.AttributesEntry
+	50, // 38: proto.TaskExecRequest.Setup.size:type_name -> proto.TaskExecRequest.TerminalSize
+	42, // 39: proto.Plan.TasksEntry.value:type_name -> proto.Task
+	59, // 40: proto.Task.VolumesEntry.value:type_name -> proto.Task.Volume
+	42, // 41: proto.Allocation.TasksEntry.value:type_name -> proto.Task
+	44, // 42: proto.Allocation.TaskStatesEntry.value:type_name -> proto.TaskState
+	65, // 43: proto.Allocation.SyncStatusEntry.value:type_name -> proto.Allocation.SyncStatus
+	42, // 44: proto.DeploymentRevision.TasksEntry.value:type_name -> proto.Task
+	36, // 45: proto.VestaService.Apply:input_type -> proto.ApplyRequest
+	13, // 46: proto.VestaService.Destroy:input_type -> proto.DestroyRequest
+	34, // 47: proto.VestaService.DeploymentList:input_type -> proto.ListDeploymentRequest
+	15, // 48: proto.VestaService.DeploymentStatus:input_type -> proto.DeploymentStatusRequest
+	17, // 49: proto.VestaService.DeploymentHistory:input_type -> proto.DeploymentHistoryRequest
+	19, // 50: proto.VestaService.DeploymentRollback:input_type -> proto.DeploymentRollbackRequest
+	28, // 51: proto.VestaService.WatchEvents:input_type -> proto.WatchEventsRequest
+	30, // 52: proto.VestaService.TaskLogs:input_type -> proto.TaskLogsRequest
+	32, // 53: proto.VestaService.TaskExec:input_type -> proto.TaskExecRequest
+	21, // 54: proto.VestaService.VolumeSnapshot:input_type -> proto.VolumeSnapshotRequest
+	23, // 55: proto.VestaService.VolumeRestore:input_type -> proto.VolumeRestoreRequest
+	25, // 56: proto.VestaService.VolumeList:input_type -> proto.VolumeListRequest
+	9,  // 57: proto.VestaService.CatalogList:input_type -> proto.CatalogListRequest
+	11, // 58: proto.VestaService.CatalogInspect:input_type -> proto.CatalogInspectRequest
+	37, // 59: proto.VestaService.Apply:output_type -> proto.ApplyResponse
+	14, // 60: proto.VestaService.Destroy:output_type -> proto.DestroyResponse
+	35, // 61: proto.VestaService.DeploymentList:output_type -> proto.ListDeploymentResponse
+	16, // 62: proto.VestaService.DeploymentStatus:output_type -> proto.DeploymentStatusResponse
+	18, // 63: proto.VestaService.DeploymentHistory:output_type -> proto.DeploymentHistoryResponse
+	20, // 64: proto.VestaService.DeploymentRollback:output_type -> proto.DeploymentRollbackResponse
+	29, // 65: proto.VestaService.WatchEvents:output_type -> proto.WatchEventsResponse
+	31, // 66: proto.VestaService.TaskLogs:output_type -> proto.TaskLogsResponse
+	33, // 67: proto.VestaService.TaskExec:output_type -> proto.TaskExecResponse
+	22, // 68: proto.VestaService.VolumeSnapshot:output_type -> proto.VolumeSnapshotResponse
+	24, // 69: proto.VestaService.VolumeRestore:output_type -> proto.VolumeRestoreResponse
+	26, // 70: proto.VestaService.VolumeList:output_type -> proto.VolumeListResponse
+	10, // 71: proto.VestaService.CatalogList:output_type -> proto.CatalogListResponse
+	12, // 72: proto.VestaService.CatalogInspect:output_type -> proto.CatalogInspectResponse
+	59, // [59:73] is the sub-list for method output_type
+	45, // [45:59] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
+}
+
+func init() { file_internal_server_proto_vesta_proto_init() }
+func file_internal_server_proto_vesta_proto_init() {
+	if File_internal_server_proto_vesta_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_internal_server_proto_vesta_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CatalogListRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CatalogListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CatalogInspectRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CatalogInspectResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DestroyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DestroyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeploymentStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeploymentStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeploymentHistoryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeploymentHistoryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeploymentRollbackRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeploymentRollbackResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VolumeSnapshotRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2011,8 +4706,8 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CatalogListResponse); i {
+		file_internal_server_proto_vesta_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VolumeSnapshotResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2023,8 +4718,8 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CatalogInspectRequest); i {
+		file_internal_server_proto_vesta_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VolumeRestoreRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2035,8 +4730,8 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CatalogInspectResponse); i {
+		file_internal_server_proto_vesta_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VolumeRestoreResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2047,8 +4742,8 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DestroyRequest); i {
+		file_internal_server_proto_vesta_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VolumeListRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2059,8 +4754,8 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DestroyResponse); i {
+		file_internal_server_proto_vesta_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VolumeListResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2071,8 +4766,8 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeploymentStatusRequest); i {
+		file_internal_server_proto_vesta_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2083,8 +4778,8 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeploymentStatusResponse); i {
+		file_internal_server_proto_vesta_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchEventsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2095,7 +4790,67 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchEventsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskLogsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskLogsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskExecRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskExecResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListDeploymentRequest); i {
 			case 0:
 				return &v.state
@@ -2107,7 +4862,7 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListDeploymentResponse); i {
 			case 0:
 				return &v.state
@@ -2119,7 +4874,7 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ApplyRequest); i {
 			case 0:
 				return &v.state
@@ -2131,7 +4886,7 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ApplyResponse); i {
 			case 0:
 				return &v.state
@@ -2143,7 +4898,31 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Plan); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Item); i {
 			case 0:
 				return &v.state
@@ -2155,7 +4934,7 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Node); i {
 			case 0:
 				return &v.state
@@ -2167,7 +4946,7 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Task); i {
 			case 0:
 				return &v.state
@@ -2179,7 +4958,7 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Allocation); i {
 			case 0:
 				return &v.state
@@ -2191,7 +4970,7 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*TaskState); i {
 			case 0:
 				return &v.state
@@ -2203,7 +4982,7 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Deployment2); i {
 			case 0:
 				return &v.state
@@ -2215,7 +4994,31 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeploymentRevision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskState2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Event2); i {
 			case 0:
 				return &v.state
@@ -2227,7 +5030,43 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskExecRequest_Setup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskExecRequest_TerminalSize); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Plan_FieldDiff); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_server_proto_vesta_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Item_Field); i {
 			case 0:
 				return &v.state
@@ -2239,7 +5078,7 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Task_Volume); i {
 			case 0:
 				return &v.state
@@ -2251,7 +5090,7 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Task_Telemetry); i {
 			case 0:
 				return &v.state
@@ -2263,7 +5102,7 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Task_Artifact); i {
 			case 0:
 				return &v.state
@@ -2275,7 +5114,7 @@ func file_internal_server_proto_vesta_proto_init() {
 				return nil
 			}
 		}
-		file_internal_server_proto_vesta_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+		file_internal_server_proto_vesta_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Allocation_SyncStatus); i {
 			case 0:
 				return &v.state
@@ -2288,13 +5127,14 @@ func file_internal_server_proto_vesta_proto_init() {
 			}
 		}
 	}
+	file_internal_server_proto_vesta_proto_msgTypes[27].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_server_proto_vesta_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   31,
+			NumEnums:      9,
+			NumMessages:   59,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
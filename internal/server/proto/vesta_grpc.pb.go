@@ -26,6 +26,14 @@ type VestaServiceClient interface {
 	Destroy(ctx context.Context, in *DestroyRequest, opts ...grpc.CallOption) (*DestroyResponse, error)
 	DeploymentList(ctx context.Context, in *ListDeploymentRequest, opts ...grpc.CallOption) (*ListDeploymentResponse, error)
 	DeploymentStatus(ctx context.Context, in *DeploymentStatusRequest, opts ...grpc.CallOption) (*DeploymentStatusResponse, error)
+	DeploymentHistory(ctx context.Context, in *DeploymentHistoryRequest, opts ...grpc.CallOption) (*DeploymentHistoryResponse, error)
+	DeploymentRollback(ctx context.Context, in *DeploymentRollbackRequest, opts ...grpc.CallOption) (*DeploymentRollbackResponse, error)
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (VestaService_WatchEventsClient, error)
+	TaskLogs(ctx context.Context, in *TaskLogsRequest, opts ...grpc.CallOption) (VestaService_TaskLogsClient, error)
+	TaskExec(ctx context.Context, opts ...grpc.CallOption) (VestaService_TaskExecClient, error)
+	VolumeSnapshot(ctx context.Context, in *VolumeSnapshotRequest, opts ...grpc.CallOption) (*VolumeSnapshotResponse, error)
+	VolumeRestore(ctx context.Context, in *VolumeRestoreRequest, opts ...grpc.CallOption) (*VolumeRestoreResponse, error)
+	VolumeList(ctx context.Context, in *VolumeListRequest, opts ...grpc.CallOption) (*VolumeListResponse, error)
 	CatalogList(ctx context.Context, in *CatalogListRequest, opts ...grpc.CallOption) (*CatalogListResponse, error)
 	CatalogInspect(ctx context.Context, in *CatalogInspectRequest, opts ...grpc.CallOption) (*CatalogInspectResponse, error)
 }
@@ -74,6 +82,146 @@ func (c *vestaServiceClient) DeploymentStatus(ctx context.Context, in *Deploymen
 	return out, nil
 }
 
+func (c *vestaServiceClient) DeploymentHistory(ctx context.Context, in *DeploymentHistoryRequest, opts ...grpc.CallOption) (*DeploymentHistoryResponse, error) {
+	out := new(DeploymentHistoryResponse)
+	err := c.cc.Invoke(ctx, "/proto.VestaService/DeploymentHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vestaServiceClient) DeploymentRollback(ctx context.Context, in *DeploymentRollbackRequest, opts ...grpc.CallOption) (*DeploymentRollbackResponse, error) {
+	out := new(DeploymentRollbackResponse)
+	err := c.cc.Invoke(ctx, "/proto.VestaService/DeploymentRollback", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vestaServiceClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (VestaService_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &VestaService_ServiceDesc.Streams[0], "/proto.VestaService/WatchEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vestaServiceWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VestaService_WatchEventsClient interface {
+	Recv() (*WatchEventsResponse, error)
+	grpc.ClientStream
+}
+
+type vestaServiceWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *vestaServiceWatchEventsClient) Recv() (*WatchEventsResponse, error) {
+	m := new(WatchEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vestaServiceClient) TaskLogs(ctx context.Context, in *TaskLogsRequest, opts ...grpc.CallOption) (VestaService_TaskLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &VestaService_ServiceDesc.Streams[1], "/proto.VestaService/TaskLogs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vestaServiceTaskLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type VestaService_TaskLogsClient interface {
+	Recv() (*TaskLogsResponse, error)
+	grpc.ClientStream
+}
+
+type vestaServiceTaskLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *vestaServiceTaskLogsClient) Recv() (*TaskLogsResponse, error) {
+	m := new(TaskLogsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vestaServiceClient) TaskExec(ctx context.Context, opts ...grpc.CallOption) (VestaService_TaskExecClient, error) {
+	stream, err := c.cc.NewStream(ctx, &VestaService_ServiceDesc.Streams[2], "/proto.VestaService/TaskExec", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &vestaServiceTaskExecClient{stream}
+	return x, nil
+}
+
+type VestaService_TaskExecClient interface {
+	Send(*TaskExecRequest) error
+	Recv() (*TaskExecResponse, error)
+	grpc.ClientStream
+}
+
+type vestaServiceTaskExecClient struct {
+	grpc.ClientStream
+}
+
+func (x *vestaServiceTaskExecClient) Send(m *TaskExecRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *vestaServiceTaskExecClient) Recv() (*TaskExecResponse, error) {
+	m := new(TaskExecResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *vestaServiceClient) VolumeSnapshot(ctx context.Context, in *VolumeSnapshotRequest, opts ...grpc.CallOption) (*VolumeSnapshotResponse, error) {
+	out := new(VolumeSnapshotResponse)
+	err := c.cc.Invoke(ctx, "/proto.VestaService/VolumeSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vestaServiceClient) VolumeRestore(ctx context.Context, in *VolumeRestoreRequest, opts ...grpc.CallOption) (*VolumeRestoreResponse, error) {
+	out := new(VolumeRestoreResponse)
+	err := c.cc.Invoke(ctx, "/proto.VestaService/VolumeRestore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vestaServiceClient) VolumeList(ctx context.Context, in *VolumeListRequest, opts ...grpc.CallOption) (*VolumeListResponse, error) {
+	out := new(VolumeListResponse)
+	err := c.cc.Invoke(ctx, "/proto.VestaService/VolumeList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *vestaServiceClient) CatalogList(ctx context.Context, in *CatalogListRequest, opts ...grpc.CallOption) (*CatalogListResponse, error) {
 	out := new(CatalogListResponse)
 	err := c.cc.Invoke(ctx, "/proto.VestaService/CatalogList", in, out, opts...)
@@ -100,6 +248,14 @@ type VestaServiceServer interface {
 	Destroy(context.Context, *DestroyRequest) (*DestroyResponse, error)
 	DeploymentList(context.Context, *ListDeploymentRequest) (*ListDeploymentResponse, error)
 	DeploymentStatus(context.Context, *DeploymentStatusRequest) (*DeploymentStatusResponse, error)
+	DeploymentHistory(context.Context, *DeploymentHistoryRequest) (*DeploymentHistoryResponse, error)
+	DeploymentRollback(context.Context, *DeploymentRollbackRequest) (*DeploymentRollbackResponse, error)
+	WatchEvents(*WatchEventsRequest, VestaService_WatchEventsServer) error
+	TaskLogs(*TaskLogsRequest, VestaService_TaskLogsServer) error
+	TaskExec(VestaService_TaskExecServer) error
+	VolumeSnapshot(context.Context, *VolumeSnapshotRequest) (*VolumeSnapshotResponse, error)
+	VolumeRestore(context.Context, *VolumeRestoreRequest) (*VolumeRestoreResponse, error)
+	VolumeList(context.Context, *VolumeListRequest) (*VolumeListResponse, error)
 	CatalogList(context.Context, *CatalogListRequest) (*CatalogListResponse, error)
 	CatalogInspect(context.Context, *CatalogInspectRequest) (*CatalogInspectResponse, error)
 	mustEmbedUnimplementedVestaServiceServer()
@@ -121,6 +277,30 @@ func (UnimplementedVestaServiceServer) DeploymentList(context.Context, *ListDepl
 func (UnimplementedVestaServiceServer) DeploymentStatus(context.Context, *DeploymentStatusRequest) (*DeploymentStatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeploymentStatus not implemented")
 }
+func (UnimplementedVestaServiceServer) DeploymentHistory(context.Context, *DeploymentHistoryRequest) (*DeploymentHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeploymentHistory not implemented")
+}
+func (UnimplementedVestaServiceServer) DeploymentRollback(context.Context, *DeploymentRollbackRequest) (*DeploymentRollbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeploymentRollback not implemented")
+}
+func (UnimplementedVestaServiceServer) WatchEvents(*WatchEventsRequest, VestaService_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedVestaServiceServer) TaskLogs(*TaskLogsRequest, VestaService_TaskLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method TaskLogs not implemented")
+}
+func (UnimplementedVestaServiceServer) TaskExec(VestaService_TaskExecServer) error {
+	return status.Errorf(codes.Unimplemented, "method TaskExec not implemented")
+}
+func (UnimplementedVestaServiceServer) VolumeSnapshot(context.Context, *VolumeSnapshotRequest) (*VolumeSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeSnapshot not implemented")
+}
+func (UnimplementedVestaServiceServer) VolumeRestore(context.Context, *VolumeRestoreRequest) (*VolumeRestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeRestore not implemented")
+}
+func (UnimplementedVestaServiceServer) VolumeList(context.Context, *VolumeListRequest) (*VolumeListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VolumeList not implemented")
+}
 func (UnimplementedVestaServiceServer) CatalogList(context.Context, *CatalogListRequest) (*CatalogListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CatalogList not implemented")
 }
@@ -212,6 +392,164 @@ func _VestaService_DeploymentStatus_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VestaService_DeploymentHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeploymentHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VestaServiceServer).DeploymentHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.VestaService/DeploymentHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VestaServiceServer).DeploymentHistory(ctx, req.(*DeploymentHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VestaService_DeploymentRollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeploymentRollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VestaServiceServer).DeploymentRollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.VestaService/DeploymentRollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VestaServiceServer).DeploymentRollback(ctx, req.(*DeploymentRollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VestaService_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VestaServiceServer).WatchEvents(m, &vestaServiceWatchEventsServer{stream})
+}
+
+type VestaService_WatchEventsServer interface {
+	Send(*WatchEventsResponse) error
+	grpc.ServerStream
+}
+
+type vestaServiceWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *vestaServiceWatchEventsServer) Send(m *WatchEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VestaService_TaskLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TaskLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(VestaServiceServer).TaskLogs(m, &vestaServiceTaskLogsServer{stream})
+}
+
+type VestaService_TaskLogsServer interface {
+	Send(*TaskLogsResponse) error
+	grpc.ServerStream
+}
+
+type vestaServiceTaskLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *vestaServiceTaskLogsServer) Send(m *TaskLogsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _VestaService_TaskExec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(VestaServiceServer).TaskExec(&vestaServiceTaskExecServer{stream})
+}
+
+type VestaService_TaskExecServer interface {
+	Send(*TaskExecResponse) error
+	Recv() (*TaskExecRequest, error)
+	grpc.ServerStream
+}
+
+type vestaServiceTaskExecServer struct {
+	grpc.ServerStream
+}
+
+func (x *vestaServiceTaskExecServer) Send(m *TaskExecResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *vestaServiceTaskExecServer) Recv() (*TaskExecRequest, error) {
+	m := new(TaskExecRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _VestaService_VolumeSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VestaServiceServer).VolumeSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.VestaService/VolumeSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VestaServiceServer).VolumeSnapshot(ctx, req.(*VolumeSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VestaService_VolumeRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VestaServiceServer).VolumeRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.VestaService/VolumeRestore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VestaServiceServer).VolumeRestore(ctx, req.(*VolumeRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VestaService_VolumeList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VolumeListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VestaServiceServer).VolumeList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.VestaService/VolumeList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VestaServiceServer).VolumeList(ctx, req.(*VolumeListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _VestaService_CatalogList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CatalogListRequest)
 	if err := dec(in); err != nil {
@@ -271,6 +609,26 @@ var VestaService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeploymentStatus",
 			Handler:    _VestaService_DeploymentStatus_Handler,
 		},
+		{
+			MethodName: "DeploymentHistory",
+			Handler:    _VestaService_DeploymentHistory_Handler,
+		},
+		{
+			MethodName: "DeploymentRollback",
+			Handler:    _VestaService_DeploymentRollback_Handler,
+		},
+		{
+			MethodName: "VolumeSnapshot",
+			Handler:    _VestaService_VolumeSnapshot_Handler,
+		},
+		{
+			MethodName: "VolumeRestore",
+			Handler:    _VestaService_VolumeRestore_Handler,
+		},
+		{
+			MethodName: "VolumeList",
+			Handler:    _VestaService_VolumeList_Handler,
+		},
 		{
 			MethodName: "CatalogList",
 			Handler:    _VestaService_CatalogList_Handler,
@@ -280,6 +638,23 @@ var VestaService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _VestaService_CatalogInspect_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _VestaService_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TaskLogs",
+			Handler:       _VestaService_TaskLogs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "TaskExec",
+			Handler:       _VestaService_TaskExec_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "internal/server/proto/vesta.proto",
 }
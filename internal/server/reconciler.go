@@ -0,0 +1,670 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
+	"github.com/umbracle/vesta/internal/backend"
+	"github.com/umbracle/vesta/internal/server/proto"
+	"github.com/umbracle/vesta/internal/server/state2"
+	"github.com/umbracle/vesta/internal/uuid"
+	"github.com/umbracle/vesta/internal/verify"
+)
+
+type reconcilerConfig struct {
+	// Interval is the period between reconcile passes
+	Interval time.Duration
+
+	// RestartDelay is the initial delay before a dead task is
+	// restarted. It doubles on each consecutive failure.
+	RestartDelay time.Duration
+
+	// MaxRestartDelay is the maximum delay between restarts
+	MaxRestartDelay time.Duration
+
+	// RestartResetWindow is the time a task must be running after
+	// a restart to reset its backoff.
+	RestartResetWindow time.Duration
+}
+
+func defaultReconcilerConfig() *reconcilerConfig {
+	return &reconcilerConfig{
+		Interval:           30 * time.Second,
+		RestartDelay:       time.Second,
+		MaxRestartDelay:    5 * time.Minute,
+		RestartResetWindow: 10 * time.Minute,
+	}
+}
+
+// reconciler converges the containers running in the backend to
+// the tasks of the deployments stored in the state.
+type reconciler struct {
+	logger  hclog.Logger
+	config  *reconcilerConfig
+	state   *state2.State
+	swarm   *backend.Swarm
+	updater backend.Updater
+
+	// lock serializes the reconcile passes with the changes done
+	// to the deployments (i.e. create or destroy)
+	lock     sync.Mutex
+	restarts map[string]*restartTracker
+
+	// loaded are the deployments with the restart trackers
+	// loaded from the stored task states
+	loaded map[string]struct{}
+
+	// verifyFailures are the tasks with artifacts that failed the
+	// verification. They are not created again until they are updated.
+	verifyFailures map[string]string
+
+	// paused are the deployments with the tasks stopped while
+	// one of their volumes is snapshotted or restored
+	paused map[string]struct{}
+
+	// fetching are the tasks with their artifacts being downloaded in
+	// the background. They are created once the artifacts are staged.
+	fetching map[string]struct{}
+	fetchWg  sync.WaitGroup
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	notifyCh chan struct{}
+	closeCh  chan struct{}
+	doneCh   chan struct{}
+}
+
+// restartTracker tracks the restarts of a single task
+type restartTracker struct {
+	// restarts is the total number of restarts of the task
+	restarts uint64
+
+	// failures is the number of consecutive restarts used for the backoff
+	failures uint64
+
+	lastRestart time.Time
+	nextRestart time.Time
+
+	// createErr is the error of the last attempt to create the
+	// container of the task if it failed
+	createErr string
+}
+
+func newReconciler(logger hclog.Logger, config *reconcilerConfig, state *state2.State, swarm *backend.Swarm, updater backend.Updater) *reconciler {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &reconciler{
+		logger:         logger.Named("reconciler"),
+		config:         config,
+		state:          state,
+		swarm:          swarm,
+		updater:        updater,
+		restarts:       map[string]*restartTracker{},
+		loaded:         map[string]struct{}{},
+		verifyFailures: map[string]string{},
+		paused:         map[string]struct{}{},
+		fetching:       map[string]struct{}{},
+		ctx:            ctx,
+		cancel:         cancel,
+		notifyCh:       make(chan struct{}, 1),
+		closeCh:        make(chan struct{}),
+		doneCh:         make(chan struct{}),
+	}
+}
+
+func (r *reconciler) start() {
+	go r.run()
+}
+
+// stop stops the reconciler and waits for the current pass and
+// the downloads of artifacts in progress to finish
+func (r *reconciler) stop() {
+	close(r.closeCh)
+	<-r.doneCh
+
+	r.cancel()
+	r.fetchWg.Wait()
+}
+
+// notify schedules a new reconcile pass
+func (r *reconciler) notify() {
+	select {
+	case r.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+func (r *reconciler) run() {
+	defer close(r.doneCh)
+
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		r.reconcileAll()
+
+		select {
+		case <-ticker.C:
+		case <-r.notifyCh:
+		case <-r.closeCh:
+			return
+		}
+	}
+}
+
+func (r *reconciler) reconcileAll() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	deployments, err := r.state.ListDeployments()
+	if err != nil {
+		r.logger.Error("failed to list deployments", "err", err)
+		return
+	}
+	for _, dep := range deployments {
+		if dep.Status != proto.Deployment2_Running {
+			continue
+		}
+		if _, ok := r.paused[dep.Id]; ok {
+			continue
+		}
+		if err := r.reconcileLocked(dep); err != nil {
+			r.logger.Error("failed to reconcile deployment", "id", dep.Id, "err", err)
+		}
+	}
+}
+
+// reconcileLocked compares the tasks of the deployment with the containers
+// in the backend and creates, restarts or removes containers to match them.
+// A task that fails does not stop the pass for the other tasks, the errors
+// are returned together at the end.
+func (r *reconciler) reconcileLocked(dep *proto.Deployment2) error {
+	deployment := dep.Id
+
+	if err := r.loadRestartsLocked(deployment); err != nil {
+		return err
+	}
+	tasks, err := r.state.GetTasks(deployment)
+	if err != nil {
+		return err
+	}
+	if len(tasks) != 0 {
+		// the tasks join the network through the network container
+		if err := r.swarm.EnsureNetwork(deployment, dep.Alias); err != nil {
+			return err
+		}
+	}
+	containers, err := r.swarm.Tasks(deployment)
+	if err != nil {
+		return err
+	}
+
+	var result error
+
+	states := []*proto.TaskState2{}
+	for name, task := range tasks {
+		c, ok := containers[name]
+		if !ok {
+			if reason, ok := r.verifyFailures[restartKey(deployment, name)]; ok {
+				states = append(states, failedTaskState(name, reason))
+				continue
+			}
+			if reason, ok := r.createBackoffLocked(deployment, name); ok {
+				states = append(states, failedTaskState(name, reason))
+				continue
+			}
+			if !r.swarm.ArtifactsStaged(task) {
+				// the task is pending until the artifacts are staged
+				r.fetchArtifactsLocked(deployment, name, task)
+				states = append(states, taskState(name, task, nil, 0))
+				continue
+			}
+
+			r.logger.Debug("create task", "deployment", deployment, "task", name)
+
+			if err := r.swarm.RunTask(deployment, name, task); err != nil {
+				var verifyErr *verify.Error
+				if errors.As(err, &verifyErr) {
+					r.verifyFailedLocked(deployment, name, verifyErr)
+					states = append(states, failedTaskState(name, verifyErr.Error()))
+					continue
+				}
+				err = fmt.Errorf("failed to create task '%s': %v", name, err)
+				r.createFailedLocked(deployment, name, err)
+				states = append(states, failedTaskState(name, err.Error()))
+				result = multierror.Append(result, err)
+				continue
+			}
+			if tracker, ok := r.restarts[restartKey(deployment, name)]; ok {
+				tracker.createErr = ""
+			}
+			states = append(states, taskState(name, task, nil, 0))
+			continue
+		}
+
+		// the list of containers does not include the exit code
+		// nor the times of the container
+		c, err := r.swarm.InspectTask(c.ID)
+		if err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+		state := taskState(name, task, c, r.restartsLocked(deployment, name))
+
+		switch c.State {
+		case backend.ContainerStateRunning, backend.ContainerStateRestarting:
+			r.trackRunning(deployment, name)
+
+		case backend.ContainerStateExited, backend.ContainerStateDead, backend.ContainerStateCreated:
+			if state.State == proto.TaskState2_Completed {
+				break
+			}
+			restarted, err := r.restartTask(deployment, name, c)
+			if err != nil {
+				result = multierror.Append(result, err)
+			}
+			if restarted {
+				state.State = proto.TaskState2_Restarting
+				state.Restarts = r.restartsLocked(deployment, name)
+			}
+		}
+		states = append(states, state)
+	}
+
+	// remove the containers that are not part of the deployment anymore
+	for name, c := range containers {
+		if _, ok := tasks[name]; ok {
+			continue
+		}
+		r.logger.Debug("remove task", "deployment", deployment, "task", name)
+
+		if err := r.swarm.RemoveTask(c.ID); err != nil {
+			result = multierror.Append(result, err)
+			continue
+		}
+		delete(r.restarts, restartKey(deployment, name))
+	}
+
+	if err := r.state.PutTaskStates(deployment, states); err != nil {
+		result = multierror.Append(result, err)
+	}
+	return result
+}
+
+// applyLocked stores the new tasks of the deployment and replaces only
+// the containers of the tasks that changed since the previous apply.
+func (r *reconciler) applyLocked(deployment string, tasks map[string]*proto.Task) ([]*proto.TaskDiff, error) {
+	oldTasks, err := r.state.GetTasks(deployment)
+	if err != nil {
+		return nil, err
+	}
+	diffs := diffTasks(oldTasks, tasks)
+
+	if err := r.state.PutTasks(deployment, tasks); err != nil {
+		return nil, err
+	}
+
+	containers, err := r.swarm.Tasks(deployment)
+	if err != nil {
+		return nil, err
+	}
+	for _, diff := range diffs {
+		// the artifacts of the updated tasks are verified again
+		delete(r.verifyFailures, restartKey(deployment, diff.Name))
+
+		if diff.Type != proto.TaskDiff_Replace {
+			continue
+		}
+		if c, ok := containers[diff.Name]; ok {
+			r.logger.Debug("replace task", "deployment", deployment, "task", diff.Name, "fields", diff.Fields)
+
+			// the container is created again with the new spec below
+			if err := r.swarm.RemoveTask(c.ID); err != nil {
+				return nil, err
+			}
+		}
+		delete(r.restarts, restartKey(deployment, diff.Name))
+	}
+
+	dep, err := r.state.GetDeploymentById(deployment)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := r.paused[deployment]; ok || dep.Status != proto.Deployment2_Running {
+		// the tasks are created once the deployment is resumed
+		return diffs, nil
+	}
+	if err := r.reconcileLocked(dep); err != nil {
+		// the tasks are stored, the ones that failed are
+		// created again by the next passes after the backoff
+		r.logger.Error("failed to reconcile deployment", "id", deployment, "err", err)
+	}
+	return diffs, nil
+}
+
+// restartTask starts again the container of a dead task unless it has
+// to wait for the backoff. It returns whether the task was restarted.
+func (r *reconciler) restartTask(deployment, name string, c *backend.Container) (bool, error) {
+	key := restartKey(deployment, name)
+
+	tracker, ok := r.restarts[key]
+	if !ok {
+		tracker = &restartTracker{}
+		r.restarts[key] = tracker
+	}
+
+	now := time.Now()
+	if now.Before(tracker.nextRestart) {
+		// wait for the backoff
+		return false, nil
+	}
+
+	r.logger.Info("restart task", "deployment", deployment, "task", name, "restarts", tracker.restarts)
+
+	if err := r.swarm.StartTask(deployment, c.ID); err != nil {
+		return false, fmt.Errorf("failed to restart task '%s': %v", name, err)
+	}
+
+	tracker.restarts++
+	tracker.failures++
+	tracker.lastRestart = now
+	tracker.nextRestart = now.Add(r.backoff(tracker.failures))
+
+	r.updater.UpdateEvent(&proto.Event2{
+		Id:         uuid.Generate(),
+		Deployment: deployment,
+		Task:       name,
+		Type:       proto.TaskRestarting,
+		Attributes: map[string]string{
+			"restarts": strconv.FormatUint(tracker.restarts, 10),
+		},
+	})
+	return true, nil
+}
+
+// loadRestartsLocked loads the restart trackers of the deployment from the
+// stored task states the first time it is reconciled so that the restarts
+// and the backoff are kept when the server restarts. The consecutive failures
+// are not stored, the restarts are taken as failures if the task was started
+// again within the reset window.
+func (r *reconciler) loadRestartsLocked(deployment string) error {
+	if _, ok := r.loaded[deployment]; ok {
+		return nil
+	}
+
+	states, err := r.state.GetTaskStates(deployment)
+	if err != nil {
+		return err
+	}
+	for _, state := range states {
+		key := restartKey(deployment, state.Name)
+		if _, ok := r.restarts[key]; ok || state.Restarts == 0 {
+			continue
+		}
+
+		tracker := &restartTracker{
+			restarts: state.Restarts,
+		}
+		if state.StartedAt != 0 {
+			tracker.lastRestart = time.Unix(state.StartedAt, 0)
+			if time.Since(tracker.lastRestart) <= r.config.RestartResetWindow {
+				tracker.failures = state.Restarts
+				tracker.nextRestart = tracker.lastRestart.Add(r.backoff(tracker.failures))
+			}
+		}
+		r.restarts[key] = tracker
+	}
+	r.loaded[deployment] = struct{}{}
+	return nil
+}
+
+// pause removes the containers of the tasks of the deployment and
+// stops reconciling the deployment until resume is called
+func (r *reconciler) pause(deployment string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.pauseLocked(deployment)
+}
+
+func (r *reconciler) pauseLocked(deployment string) error {
+	if _, ok := r.paused[deployment]; ok {
+		return fmt.Errorf("deployment '%s' has a volume operation in progress", deployment)
+	}
+
+	if err := r.loadRestartsLocked(deployment); err != nil {
+		return err
+	}
+	tasks, err := r.state.GetTasks(deployment)
+	if err != nil {
+		return err
+	}
+	containers, err := r.swarm.Tasks(deployment)
+	if err != nil {
+		return err
+	}
+	for name, c := range containers {
+		r.logger.Debug("stop task", "deployment", deployment, "task", name)
+
+		if err := r.swarm.RemoveTask(c.ID); err != nil {
+			return err
+		}
+	}
+	r.paused[deployment] = struct{}{}
+
+	states := []*proto.TaskState2{}
+	for name, task := range tasks {
+		states = append(states, taskState(name, task, nil, r.restartsLocked(deployment, name)))
+	}
+	return r.state.PutTaskStates(deployment, states)
+}
+
+// resume creates again the tasks of a paused deployment
+func (r *reconciler) resume(deployment string) {
+	r.lock.Lock()
+	delete(r.paused, deployment)
+	r.lock.Unlock()
+
+	r.notify()
+}
+
+// isPausedLocked returns whether the deployment is paused
+func (r *reconciler) isPausedLocked(deployment string) bool {
+	_, ok := r.paused[deployment]
+	return ok
+}
+
+// verifyFailedLocked records that the artifacts of a task failed the verification.
+// The dead state of the task is stored before the event is emitted since the
+// verification may fail in the background, outside of a reconcile pass.
+func (r *reconciler) verifyFailedLocked(deployment, name string, err *verify.Error) {
+	r.logger.Error("failed to verify artifact", "deployment", deployment, "task", name, "source", err.Source, "reason", err.Reason)
+
+	r.verifyFailures[restartKey(deployment, name)] = err.Error()
+	if err := r.putTaskStateLocked(deployment, failedTaskState(name, err.Error())); err != nil {
+		r.logger.Error("failed to store task state", "deployment", deployment, "task", name, "err", err)
+	}
+	r.updater.UpdateEvent(&proto.Event2{
+		Id:         uuid.Generate(),
+		Deployment: deployment,
+		Task:       name,
+		Type:       proto.TaskVerificationFailed,
+		Attributes: map[string]string{
+			"source": err.Source,
+			"reason": err.Reason,
+		},
+	})
+}
+
+// putTaskStateLocked replaces the stored state of a single task of the deployment
+func (r *reconciler) putTaskStateLocked(deployment string, state *proto.TaskState2) error {
+	current, err := r.state.GetTaskStates(deployment)
+	if err != nil {
+		return err
+	}
+	states := []*proto.TaskState2{state}
+	for _, s := range current {
+		if s.Name != state.Name {
+			states = append(states, s)
+		}
+	}
+	return r.state.PutTaskStates(deployment, states)
+}
+
+// fetchArtifactsLocked downloads the artifacts of a task in the background
+// so that the reconciler lock is not held during the download. A new pass
+// is scheduled once they are fetched to create the task.
+func (r *reconciler) fetchArtifactsLocked(deployment, name string, task *proto.Task) {
+	key := restartKey(deployment, name)
+	if _, ok := r.fetching[key]; ok {
+		return
+	}
+	r.fetching[key] = struct{}{}
+
+	r.logger.Debug("fetch artifacts", "deployment", deployment, "task", name)
+
+	r.fetchWg.Add(1)
+	go func() {
+		defer r.fetchWg.Done()
+
+		err := r.swarm.FetchArtifacts(r.ctx, task)
+
+		r.lock.Lock()
+		defer r.lock.Unlock()
+
+		delete(r.fetching, key)
+		if r.ctx.Err() != nil {
+			// the reconciler is stopping
+			return
+		}
+		if err != nil && r.sameArtifactsLocked(deployment, name, task) {
+			var verifyErr *verify.Error
+			if errors.As(err, &verifyErr) {
+				r.verifyFailedLocked(deployment, name, verifyErr)
+			} else {
+				r.createFailedLocked(deployment, name, fmt.Errorf("failed to fetch the artifacts of task '%s': %v", name, err))
+			}
+		}
+		r.notify()
+	}()
+}
+
+// sameArtifactsLocked returns whether the stored task still has the
+// artifacts of the given task (i.e. it was not updated nor removed)
+func (r *reconciler) sameArtifactsLocked(deployment, name string, task *proto.Task) bool {
+	tasks, err := r.state.GetTasks(deployment)
+	if err != nil {
+		return false
+	}
+	current, ok := tasks[name]
+	if !ok {
+		return false
+	}
+	for _, field := range diffTask(current, task) {
+		if field == "artifacts" {
+			return false
+		}
+	}
+	return true
+}
+
+// createFailedLocked records that the container of a task could not be
+// created. The task is created again after the backoff.
+func (r *reconciler) createFailedLocked(deployment, name string, err error) {
+	r.logger.Error("failed to create task", "deployment", deployment, "task", name, "err", err)
+
+	key := restartKey(deployment, name)
+	tracker, ok := r.restarts[key]
+	if !ok {
+		tracker = &restartTracker{}
+		r.restarts[key] = tracker
+	}
+
+	now := time.Now()
+	tracker.failures++
+	tracker.lastRestart = now
+	tracker.nextRestart = now.Add(r.backoff(tracker.failures))
+	tracker.createErr = err.Error()
+}
+
+// createBackoffLocked returns the error of the last attempt to create
+// the task if it has to wait for the backoff before trying again
+func (r *reconciler) createBackoffLocked(deployment, name string) (string, bool) {
+	tracker, ok := r.restarts[restartKey(deployment, name)]
+	if !ok || tracker.createErr == "" {
+		return "", false
+	}
+	if !time.Now().Before(tracker.nextRestart) {
+		return "", false
+	}
+	return tracker.createErr, true
+}
+
+func (r *reconciler) trackRunning(deployment, name string) {
+	tracker, ok := r.restarts[restartKey(deployment, name)]
+	if !ok {
+		return
+	}
+	if time.Since(tracker.lastRestart) > r.config.RestartResetWindow {
+		// the task has been running long enough since the
+		// last restart, reset the backoff
+		tracker.failures = 0
+		tracker.nextRestart = time.Time{}
+	}
+}
+
+func (r *reconciler) backoff(failures uint64) time.Duration {
+	delay := r.config.RestartDelay
+	for i := uint64(1); i < failures; i++ {
+		delay *= 2
+		if delay >= r.config.MaxRestartDelay {
+			return r.config.MaxRestartDelay
+		}
+	}
+	return delay
+}
+
+// taskRestarts returns the number of times a task has been restarted
+func (r *reconciler) taskRestarts(deployment, name string) uint64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.restartsLocked(deployment, name)
+}
+
+func (r *reconciler) restartsLocked(deployment, name string) uint64 {
+	tracker, ok := r.restarts[restartKey(deployment, name)]
+	if !ok {
+		return 0
+	}
+	return tracker.restarts
+}
+
+// forgetLocked removes the restart trackers of a deployment
+func (r *reconciler) forgetLocked(deployment string) {
+	delete(r.paused, deployment)
+	delete(r.loaded, deployment)
+
+	for key := range r.restarts {
+		if strings.HasPrefix(key, deployment+"/") {
+			delete(r.restarts, key)
+		}
+	}
+	for key := range r.verifyFailures {
+		if strings.HasPrefix(key, deployment+"/") {
+			delete(r.verifyFailures, key)
+		}
+	}
+}
+
+func restartKey(deployment, name string) string {
+	return deployment + "/" + name
+}
@@ -0,0 +1,15 @@
+//go:build linux
+
+package server
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// reflink clones the content of src into dst without copying the data
+// on filesystems with copy-on-write support (i.e. btrfs, xfs).
+func reflink(dst, src *os.File) error {
+	return unix.IoctlFileClone(int(dst.Fd()), int(src.Fd()))
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package server
+
+import (
+	"fmt"
+	"os"
+)
+
+func reflink(dst, src *os.File) error {
+	return fmt.Errorf("reflink is not supported")
+}
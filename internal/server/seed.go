@@ -0,0 +1,317 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/umbracle/vesta/internal/server/proto"
+	"github.com/umbracle/vesta/internal/uuid"
+	gproto "google.golang.org/protobuf/proto"
+)
+
+// seedStopReason is the reason of the deployments whose volumes are
+// being copied. A deployment keeps it if the server stops mid-copy.
+const seedStopReason = "seed"
+
+// createSeeded creates a new deployment whose volumes are copied from the
+// volumes of another deployment of the same plugin and chain (or restored
+// from a snapshot of one) before its tasks start for the first time. The
+// copy runs in the background since it can take hours for the database
+// of a chain.
+func (s *Server) createSeeded(req *proto.ApplyRequest) (string, []*proto.TaskDiff, error) {
+	source, snapshot, req, err := s.seedSource(req)
+	if err != nil {
+		return "", nil, err
+	}
+
+	s.reconciler.lock.Lock()
+	defer s.reconciler.lock.Unlock()
+
+	id, diffs, err := s.createLocked(req, true)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var attrs map[string]string
+	var copy func() error
+
+	if snapshot != nil {
+		attrs = map[string]string{"snapshot": snapshot.Id}
+		copy = func() error {
+			return s.restoreSeed(snapshot, id)
+		}
+		_, err = s.volumeDeployment(id, snapshot.Task, snapshot.Volume)
+	} else {
+		var volumes []string
+		attrs = map[string]string{"source": source.Id}
+		copy = func() error {
+			return s.copyVolumes(source.Id, id, volumes)
+		}
+		volumes, err = s.seedVolumes(source.Id, id)
+	}
+	if err == nil {
+		// the deployment is not started if the server stops before the copy is complete
+		err = s.state2.StopDeployment(id, seedStopReason)
+	}
+	if err != nil {
+		// remove the deployment so that it does not start from an empty state
+		s.removeSeededLocked(id)
+		return "", nil, err
+	}
+
+	s.seeds.Add(1)
+	go func() {
+		defer s.seeds.Done()
+		s.copySeed(id, attrs, copy)
+	}()
+
+	return id, diffs, nil
+}
+
+// copySeed runs the copy of the volumes of the deployment and starts its tasks
+// once it is complete. The attributes identify the source of the copy in the events.
+func (s *Server) copySeed(deployment string, attrs map[string]string, copy func() error) {
+	err := copy()
+	if err == nil {
+		// the deployment cannot be destroyed while it is paused
+		err = s.state2.UpdateDeploymentStatus(deployment, proto.Deployment2_Running)
+	}
+	if err != nil {
+		s.logger.Error("failed to copy the volumes of the deployment", "id", deployment, "err", err)
+
+		failedAttrs := map[string]string{"error": err.Error()}
+		for k, v := range attrs {
+			failedAttrs[k] = v
+		}
+		s.UpdateEvent(&proto.Event2{
+			Id:         uuid.Generate(),
+			Deployment: deployment,
+			Type:       proto.DeploymentSeedFailed,
+			Attributes: failedAttrs,
+		})
+
+		s.reconciler.lock.Lock()
+		s.removeSeededLocked(deployment)
+		s.reconciler.lock.Unlock()
+		return
+	}
+
+	s.UpdateEvent(&proto.Event2{
+		Id:         uuid.Generate(),
+		Deployment: deployment,
+		Type:       proto.DeploymentSeeded,
+		Attributes: attrs,
+	})
+	s.reconciler.resume(deployment)
+}
+
+// removeSeededLocked destroys a deployment whose volumes could not be copied
+func (s *Server) removeSeededLocked(id string) {
+	dep, err := s.state2.GetDeploymentById(id)
+	if err == nil {
+		err = s.destroyLocked(dep, true)
+	}
+	if err != nil {
+		s.logger.Error("failed to destroy seeded deployment", "id", id, "err", err)
+	}
+}
+
+// seedSource returns the deployment that seeds the request (or the one of the
+// snapshot that seeds it) and a copy of the request with the plugin and the
+// chain of that deployment if not set.
+func (s *Server) seedSource(req *proto.ApplyRequest) (*proto.Deployment2, *proto.Snapshot, *proto.ApplyRequest, error) {
+	if req.AllocationId != "" {
+		return nil, nil, nil, fmt.Errorf("only new deployments can be seeded from another deployment or a snapshot")
+	}
+	if req.FromDeployment != "" && req.FromSnapshot != "" {
+		return nil, nil, nil, fmt.Errorf("a deployment cannot be seeded from both a deployment and a snapshot")
+	}
+
+	var source *proto.Deployment2
+	var snapshot *proto.Snapshot
+	var err error
+
+	if req.FromSnapshot != "" {
+		if snapshot, err = s.state2.GetSnapshotByIdOrPrefix(req.FromSnapshot); err != nil {
+			return nil, nil, nil, err
+		}
+		// the deployment of the snapshot may be destroyed
+		if source, err = s.state2.GetDeploymentById(snapshot.Deployment); err != nil {
+			return nil, nil, nil, err
+		}
+	} else {
+		if source, err = s.state2.GetDeploymentByIdOrPrefix(req.FromDeployment); err != nil {
+			return nil, nil, nil, err
+		}
+		if source.Status == proto.Deployment2_Destroyed {
+			return nil, nil, nil, fmt.Errorf("deployment '%s' is destroyed", source.Id)
+		}
+	}
+
+	req = gproto.Clone(req).(*proto.ApplyRequest)
+	if req.Action == "" {
+		req.Action = source.Action
+	} else if !strings.EqualFold(req.Action, source.Action) {
+		return nil, nil, nil, fmt.Errorf("plugin '%s' does not match the plugin '%s' of deployment '%s'", req.Action, source.Action, source.Id)
+	}
+	if req.Chain == "" {
+		req.Chain = source.Chain
+	} else if req.Chain != source.Chain {
+		return nil, nil, nil, fmt.Errorf("chain '%s' does not match the chain '%s' of deployment '%s'", req.Chain, source.Chain, source.Id)
+	}
+	if snapshot != nil {
+		req.FromSnapshot = snapshot.Id
+	} else {
+		req.FromDeployment = source.Id
+	}
+
+	return source, snapshot, req, nil
+}
+
+// seedVolumes returns the volumes of the deployment (as task/volume) that
+// have data to copy in the source deployment.
+func (s *Server) seedVolumes(source, deployment string) ([]string, error) {
+	tasks, err := s.state2.GetTasks(deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes := []string{}
+	for name, task := range tasks {
+		for volume := range task.Volumes {
+			if _, err := os.Stat(s.swarm.VolumePath(source, name, volume)); os.IsNotExist(err) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			volumes = append(volumes, name+"/"+volume)
+		}
+	}
+	if len(volumes) == 0 {
+		return nil, fmt.Errorf("deployment '%s' has no volumes to copy", source)
+	}
+	sort.Strings(volumes)
+	return volumes, nil
+}
+
+// copyVolumes copies the volumes of the source deployment into the volumes
+// with the same task and name of the deployment. The tasks of the source
+// deployment are stopped during the copy so that the data is consistent.
+func (s *Server) copyVolumes(source, deployment string, volumes []string) error {
+	if err := s.reconciler.pause(source); err != nil {
+		return err
+	}
+	defer s.reconciler.resume(source)
+
+	for _, v := range volumes {
+		name, volume, _ := strings.Cut(v, "/")
+
+		s.logger.Info("copy volume", "source", source, "deployment", deployment, "task", name, "volume", volume)
+
+		src, dst := s.swarm.VolumePath(source, name, volume), s.swarm.VolumePath(deployment, name, volume)
+		if err := copyDir(s.seedCtx, src, dst); err != nil {
+			return fmt.Errorf("failed to copy volume '%s' of task '%s': %v", volume, name, err)
+		}
+	}
+	return nil
+}
+
+// restoreSeed restores the snapshot into the volume with the same
+// task and name of the deployment
+func (s *Server) restoreSeed(snapshot *proto.Snapshot, deployment string) error {
+	s.logger.Info("restore volume", "deployment", deployment, "task", snapshot.Task, "volume", snapshot.Volume, "snapshot", snapshot.Id)
+
+	if err := extractArchive(s.seedCtx, snapshot.Path, s.swarm.VolumePath(deployment, snapshot.Task, snapshot.Volume), snapshot.Compressed); err != nil {
+		return fmt.Errorf("failed to restore snapshot '%s': %v", snapshot.Id, err)
+	}
+	return nil
+}
+
+// copyDir copies the content of the directory src into dst. The files are
+// cloned if the filesystem supports it. As with extractArchive, dst is only
+// replaced once the copy is complete. The copy stops if ctx is cancelled.
+func copyDir(ctx context.Context, src, dst string) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp(filepath.Dir(dst), ".seed-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	err = filepath.WalkDir(src, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(tmpDir, rel)
+		switch mode := info.Mode(); {
+		case rel == ".":
+			return os.Chmod(tmpDir, mode.Perm())
+
+		case mode.IsDir():
+			return os.Mkdir(target, mode.Perm())
+
+		case mode&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+
+		case mode.IsRegular():
+			return copyFile(path, target, mode.Perm())
+
+		default:
+			// skip sockets, devices and pipes
+			return nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+	return os.Rename(tmpDir, dst)
+}
+
+func copyFile(src, dst string, perm os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+	if err := reflink(out, in); err != nil {
+		// the filesystem cannot clone the file, copy the content instead
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+	}
+	return out.Close()
+}
@@ -0,0 +1,212 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/mock"
+	"github.com/umbracle/vesta/internal/server/proto"
+	"github.com/umbracle/vesta/internal/testutil"
+)
+
+func TestCreate_Seeded(t *testing.T) {
+	task := mock.Task()
+	task.Volumes = map[string]*proto.Task_Volume{
+		"data": {Path: "/data"},
+	}
+	catalog := &dummyCatalog{
+		createTask: task,
+	}
+	srv, driver := testServer(t, catalog)
+
+	source, _, err := srv.Create(&proto.ApplyRequest{Action: "geth", Chain: "goerli", Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	volume := srv.swarm.VolumePath(source, "task", "data")
+	require.NoError(t, os.MkdirAll(filepath.Join(volume, "geth"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(volume, "geth", "chaindata"), []byte("block 1"), 0644))
+
+	// the plugin and the chain must match the ones of the source
+	_, _, err = srv.Create(&proto.ApplyRequest{Action: "prysm", FromDeployment: source})
+	require.Error(t, err)
+
+	_, _, err = srv.Create(&proto.ApplyRequest{Chain: "mainnet", FromDeployment: source})
+	require.Error(t, err)
+
+	// only new deployments can be seeded
+	_, _, err = srv.Create(&proto.ApplyRequest{AllocationId: source, FromDeployment: source})
+	require.Error(t, err)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x2}, FromDeployment: source[:8]})
+	require.NoError(t, err)
+
+	dep, err := srv.state2.GetDeploymentById(id)
+	require.NoError(t, err)
+	require.Equal(t, "geth", dep.Action)
+	require.Equal(t, "goerli", dep.Chain)
+
+	// the volumes are copied in the background
+	waitForEvents(t, srv, id, proto.DeploymentSeeded)
+
+	dep, err = srv.state2.GetDeploymentById(id)
+	require.NoError(t, err)
+	require.Equal(t, proto.Deployment2_Running, dep.Status)
+
+	data, err := os.ReadFile(filepath.Join(srv.swarm.VolumePath(id, "task", "data"), "geth", "chaindata"))
+	require.NoError(t, err)
+	require.Equal(t, "block 1", string(data))
+
+	// both deployments run after the copy
+	for _, id := range []string{source, id} {
+		id := id
+		testutil.WaitForResult(func() (bool, error) {
+			if driver.Spec("task-"+id) == nil {
+				return false, fmt.Errorf("task not created")
+			}
+			return true, nil
+		}, func(err error) {
+			t.Fatal(err)
+		})
+	}
+}
+
+func TestCreate_SeededFailed(t *testing.T) {
+	task := mock.Task()
+	task.Volumes = map[string]*proto.Task_Volume{
+		"data": {Path: "/data"},
+	}
+	srv, _ := testServer(t, &dummyCatalog{createTask: task})
+
+	source, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(srv.swarm.VolumePath(source, "task", "data"), 0755))
+
+	// the source cannot be stopped while it has another volume operation
+	require.NoError(t, srv.reconciler.pause(source))
+	defer srv.reconciler.resume(source)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x2}, FromDeployment: source})
+	require.NoError(t, err)
+
+	// the deployment is removed if the copy fails
+	waitForEvents(t, srv, id, proto.DeploymentSeedFailed)
+
+	testutil.WaitForResult(func() (bool, error) {
+		dep, err := srv.state2.GetDeploymentById(id)
+		if err != nil {
+			return false, err
+		}
+		if dep.Status != proto.Deployment2_Destroyed {
+			return false, fmt.Errorf("deployment is %s", dep.Status)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
+
+func TestCreate_FromSnapshot(t *testing.T) {
+	task := mock.Task()
+	task.Volumes = map[string]*proto.Task_Volume{
+		"data": {Path: "/data"},
+	}
+	catalog := &dummyCatalog{
+		createTask: task,
+	}
+	srv, driver := testServer(t, catalog)
+
+	source, _, err := srv.Create(&proto.ApplyRequest{Action: "geth", Chain: "goerli", Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	volume := srv.swarm.VolumePath(source, "task", "data")
+	require.NoError(t, os.MkdirAll(volume, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(volume, "chaindata"), []byte("block 1"), 0644))
+
+	snapshot, err := srv.VolumeSnapshot(&proto.VolumeSnapshotRequest{Deployment: source, Task: "task", Volume: "data"})
+	require.NoError(t, err)
+
+	// the chain must match the one of the deployment of the snapshot
+	_, _, err = srv.Create(&proto.ApplyRequest{Chain: "mainnet", FromSnapshot: snapshot.Id})
+	require.Error(t, err)
+
+	// and it cannot be seeded from a deployment too
+	_, _, err = srv.Create(&proto.ApplyRequest{FromDeployment: source, FromSnapshot: snapshot.Id})
+	require.Error(t, err)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x2}, FromSnapshot: snapshot.Id[:8]})
+	require.NoError(t, err)
+
+	dep, err := srv.state2.GetDeploymentById(id)
+	require.NoError(t, err)
+	require.Equal(t, "geth", dep.Action)
+	require.Equal(t, "goerli", dep.Chain)
+
+	// the snapshot is restored in the background
+	waitForEvents(t, srv, id, proto.DeploymentSeeded)
+
+	data, err := os.ReadFile(filepath.Join(srv.swarm.VolumePath(id, "task", "data"), "chaindata"))
+	require.NoError(t, err)
+	require.Equal(t, "block 1", string(data))
+
+	testutil.WaitForResult(func() (bool, error) {
+		if driver.Spec("task-"+id) == nil {
+			return false, fmt.Errorf("task not created")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
+
+func TestCreate_SeededNoVolumes(t *testing.T) {
+	srv, _ := testServer(t, &dummyCatalog{createTask: mock.Task()})
+
+	source, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	// the deployment is removed if there is nothing to copy
+	_, _, err = srv.Create(&proto.ApplyRequest{Input: []byte{0x2}, FromDeployment: source})
+	require.Error(t, err)
+
+	deps, err := srv.state2.ListDeployments()
+	require.NoError(t, err)
+
+	running := 0
+	for _, dep := range deps {
+		if dep.Status == proto.Deployment2_Running {
+			running++
+		}
+	}
+	require.Equal(t, 1, running)
+}
+
+func TestCopyDir(t *testing.T) {
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "a", "b"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "a", "b", "file"), []byte("data"), 0600))
+	require.NoError(t, os.Symlink("a/b/file", filepath.Join(src, "link")))
+
+	dst := filepath.Join(t.TempDir(), "volume")
+	require.NoError(t, copyDir(context.Background(), src, dst))
+
+	data, err := os.ReadFile(filepath.Join(dst, "link"))
+	require.NoError(t, err)
+	require.Equal(t, "data", string(data))
+
+	info, err := os.Stat(filepath.Join(dst, "a", "b", "file"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	info, err = os.Stat(filepath.Join(dst, "a"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0700), info.Mode().Perm())
+
+	// no temporary directories are left behind
+	entries, err := os.ReadDir(filepath.Dir(dst))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
@@ -1,12 +1,17 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/boltdb/bolt"
 	"github.com/hashicorp/go-hclog"
 	"github.com/umbracle/vesta/internal/backend"
 	"github.com/umbracle/vesta/internal/catalog"
@@ -14,25 +19,65 @@ import (
 	"github.com/umbracle/vesta/internal/server/state2"
 	"github.com/umbracle/vesta/internal/uuid"
 	"google.golang.org/grpc"
+	gproto "google.golang.org/protobuf/proto"
 )
 
 type Config struct {
-	GrpcAddr     string
-	PersistentDB *bolt.DB
-	Catalog      []string
+	GrpcAddr string
+	Catalog  []string
+
+	// DataDir is the directory that holds all the state of the server
+	DataDir string
+
+	// Driver is the container runtime used to run the tasks.
+	// It defaults to Docker if not set.
+	Driver backend.Driver
+
+	// MetricsAddr is the address of the HTTP server that exposes
+	// the metrics of the server. It is disabled if empty.
+	MetricsAddr string
+
+	// EventMaxAge is the maximum age of the events stored
+	EventMaxAge time.Duration
+
+	// EventMaxPerDeployment is the maximum number of events
+	// stored for each deployment
+	EventMaxPerDeployment int
+
+	// DiskWarnThreshold is the percentage of used space of the host
+	// filesystem that emits warning events. Zero disables it.
+	DiskWarnThreshold float64
+
+	// DiskStopThreshold is the percentage of used space of the host
+	// filesystem that stops the deployments. Zero disables it.
+	DiskStopThreshold float64
 }
 
 // DefaultConfig returns a default configuration
 func DefaultConfig() *Config {
+	compactor := defaultCompactorConfig()
+	disk := defaultDiskMonitorConfig()
+
 	return &Config{
-		GrpcAddr: "localhost:4003",
+		GrpcAddr:              "localhost:4003",
+		DataDir:               defaultDataDir(),
+		EventMaxAge:           compactor.MaxAge,
+		EventMaxPerDeployment: compactor.MaxEvents,
+		DiskWarnThreshold:     disk.WarnThreshold,
+		DiskStopThreshold:     disk.StopThreshold,
 	}
 }
 
 type Catalog interface {
-	Build(prev []byte, req *proto.ApplyRequest) ([]byte, map[string]*proto.Task, error)
+	// Build returns the state and the tasks of the deployment and the
+	// secrets generated for it, which are stored with the deployment
+	Build(prev []byte, req *proto.ApplyRequest) ([]byte, map[string]*proto.Task, map[string]string, error)
+	Diff(prev []byte, req *proto.ApplyRequest) ([]*proto.Plan_FieldDiff, error)
 	ListPlugins() []string
 	GetPlugin(name string) (*proto.Item, error)
+	// References returns the ids of the deployments referenced
+	// by the state of a deployment of the plugin
+	References(plugin string, spec []byte) ([]string, error)
 }
 
 type Server struct {
@@ -40,30 +85,31 @@ type Server struct {
 	grpcServer *grpc.Server
 	state2     *state2.State
 	// state      *state.StateStore
-	catalog Catalog
-	swarm   *backend.Swarm
-}
+	catalog    Catalog
+	swarm      *backend.Swarm
+	reconciler *reconciler
+	compactor  *compactor
+	disk       *diskMonitor
 
-func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
-	//var statedb *state.StateStore
+	metricsServer *http.Server
 
-	/*
-		if config.PersistentDB != nil {
-			s, err := state.NewStateStoreWithBoltDB(config.PersistentDB)
-			if err != nil {
-				return nil, err
-			}
-			statedb = s
+	broker    *eventBroker
+	eventLock sync.Mutex
 
-		} else {
-			s, err := state.NewStateStore("server.db")
-			if err != nil {
-				return nil, err
-			}
-			statedb = s
-		}
-	*/
+	// snapshotsDir is the default directory of the snapshots of the volumes
+	snapshotsDir string
+
+	// seeds tracks the copies of the volumes of the seeded deployments,
+	// they are interrupted when seedCtx is cancelled
+	seeds      sync.WaitGroup
+	seedCtx    context.Context
+	seedCancel context.CancelFunc
+
+	// unlockDataDir releases the lock of the data dir
+	unlockDataDir func() error
+}
 
+func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 	catalog, err := catalog.NewCatalog()
 	if err != nil {
 		return nil, err
@@ -78,31 +124,111 @@ func NewServer(logger hclog.Logger, config *Config) (*Server, error) {
 		}
 	}
 
-	state, err := state2.NewState("example.db")
+	// the paths of the data dir are mounted in the containers
+	dataDir, err := filepath.Abs(config.DataDir)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := LockDataDir(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	if err := setupDataDir(dataDir); err != nil {
+		unlock()
+		return nil, err
+	}
+	imported, err := ImportLegacyState(dataDir)
+	if err != nil {
+		unlock()
+		return nil, err
+	}
+	if imported != "" {
+		logger.Warn("imported the state of a previous version into the data dir, the old database is not used anymore and it can be removed", "path", imported, "data-dir", dataDir)
+	}
+
+	state, err := state2.NewState(StatePath(dataDir))
 	if err != nil {
+		unlock()
 		return nil, err
 	}
 
+	// the reference fields point to the deployments in the state
+	catalog.SetResolver(state)
+
 	srv := &Server{
-		logger: logger,
-		// state:   statedb,
-		state2:  state,
-		catalog: catalog,
+		logger:        logger,
+		state2:        state,
+		catalog:       catalog,
+		broker:        newEventBroker(),
+		snapshotsDir:  filepath.Join(dataDir, dataDirSnapshots),
+		unlockDataDir: unlock,
+	}
+	srv.seedCtx, srv.seedCancel = context.WithCancel(context.Background())
+
+	driver := config.Driver
+	if driver == nil {
+		if driver, err = backend.NewDockerDriver(); err != nil {
+			srv.closeDataDir()
+			return nil, err
+		}
+	}
+	swarmConfig := &backend.SwarmConfig{
+		DataDir:      filepath.Join(dataDir, dataDirData),
+		VolumesDir:   filepath.Join(dataDir, dataDirVolumes),
+		ArtifactsDir: filepath.Join(dataDir, dataDirArtifacts),
+	}
+	srv.swarm = backend.NewSwarm(logger, driver, srv, swarmConfig)
+	if err := srv.swarm.CreateNetwork(); err != nil {
+		srv.swarm.Stop()
+		srv.closeDataDir()
+		return nil, err
 	}
+	srv.reconciler = newReconciler(logger, defaultReconcilerConfig(), srv.state2, srv.swarm, srv)
+	srv.reconciler.start()
+
+	compactorConfig := defaultCompactorConfig()
+	compactorConfig.MaxAge = config.EventMaxAge
+	compactorConfig.MaxEvents = config.EventMaxPerDeployment
+
+	srv.compactor = newCompactor(logger, compactorConfig, srv.state2)
+	srv.compactor.start()
+
+	diskConfig := defaultDiskMonitorConfig()
+	diskConfig.WarnThreshold = config.DiskWarnThreshold
+	diskConfig.StopThreshold = config.DiskStopThreshold
 
-	srv.swarm = backend.NewSwarm(srv)
+	srv.disk = newDiskMonitor(logger, diskConfig, swarmConfig.VolumesDir, srv.reconciler)
+	srv.disk.start()
 
 	if err := srv.setupGRPCServer(config.GrpcAddr); err != nil {
+		srv.Stop()
+		return nil, err
+	}
+	if err := srv.setupMetricsServer(config.MetricsAddr); err != nil {
+		srv.Stop()
 		return nil, err
 	}
 	return srv, nil
 }
 
 func (s *Server) UpdateEvent(event *proto.Event2) {
+	describeEvent(event)
+
 	s.logger.Info("creating event", "deployment", event.Deployment, "task", event.Task, "type", event.Type)
 
+	// the events are published in the same order as they are stored
+	s.eventLock.Lock()
 	if err := s.state2.CreateEvent(event); err != nil {
 		s.logger.Error("failed to create event", "err", err)
+	} else {
+		s.broker.publish(event)
+	}
+	s.eventLock.Unlock()
+
+	if event.Type == "die" || event.Type == "destroy" || event.Type == "start" {
+		// a task might need to be restarted or created again
+		// and its state has to be updated
+		s.reconciler.notify()
 	}
 }
 
@@ -140,65 +266,568 @@ func (s *Server) loggingServerInterceptor(ctx context.Context, req interface{},
 }
 
 func (s *Server) Stop() {
-	s.grpcServer.Stop()
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+	s.stopMetricsServer()
+	s.seedCancel()
+	s.seeds.Wait()
+	s.reconciler.stop()
+	s.compactor.stop()
+	s.disk.stop()
+	s.swarm.Stop()
+	s.broker.close()
+	s.closeDataDir()
+}
+
+// closeDataDir closes the state and releases the data dir
+func (s *Server) closeDataDir() {
+	if err := s.state2.Close(); err != nil {
+		s.logger.Error("failed to close state", "err", err)
+	}
+	if s.unlockDataDir != nil {
+		if err := s.unlockDataDir(); err != nil {
+			s.logger.Error("failed to unlock data dir", "err", err)
+		}
+	}
 }
 
-func (s *Server) Create(req *proto.ApplyRequest) (string, error) {
-	allocId := req.AllocationId
+func (s *Server) Create(req *proto.ApplyRequest) (string, []*proto.TaskDiff, error) {
+	if req.FromDeployment != "" || req.FromSnapshot != "" {
+		return s.createSeeded(req)
+	}
 
-	var alloc *proto.Deployment2
-	var prevState []byte
+	s.reconciler.lock.Lock()
+	defer s.reconciler.lock.Unlock()
 
-	if allocId != "" {
-		// load allocation from the state
-		var err error
+	return s.createLocked(req, false)
+}
 
-		if alloc, err = s.state2.GetDeploymentById(allocId); err != nil {
-			return "", err
-		}
+// createLocked creates or updates the deployment of the request. If paused
+// is set, the tasks of a new deployment are not started until it is resumed.
+func (s *Server) createLocked(req *proto.ApplyRequest, paused bool) (string, []*proto.TaskDiff, error) {
+	alloc, req, err := s.resolveRequest(req)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var prevState []byte
+	if alloc != nil {
 		prevState = alloc.Spec
 	}
 
-	_, deployableTasks, err := s.catalog.Build(prevState, req)
+	var allocId string
+	if alloc == nil {
+		// the plugin builds the deployment with its id
+		allocId = uuid.Generate()
+		req.AllocationId = allocId
+	}
+
+	newState, deployableTasks, secrets, err := s.catalog.Build(prevState, req)
 	if err != nil {
-		return "", fmt.Errorf("failed to run plugin '%s': %v", req.Action, err)
+		return "", nil, fmt.Errorf("failed to run plugin '%s': %v", req.Action, err)
 	}
 
 	if alloc != nil {
-		s.logger.Info("updating deployment")
+		allocId = alloc.Id
+
+		s.logger.Info("updating deployment", "id", allocId)
 
 		// update the deployment
-		if err := s.state2.UpdateDeployment(alloc); err != nil {
-			return "", err
+		alloc.Spec = newState
+		alloc.Action = req.Action
+		alloc.Chain = req.Chain
+		alloc.Metrics = req.GetMetrics()
+		alloc.Alias = req.Alias
+		if err := s.state2.UpdateDeployment(alloc, secrets); err != nil {
+			return "", nil, err
 		}
 	} else {
 		// create a new deployment
-		allocId = uuid.Generate()
-
 		s.logger.Info("creating deployment", "id", allocId)
 
 		alloc := &proto.Deployment2{
-			Id:   allocId,
-			Spec: req.Input,
+			Id:      allocId,
+			Spec:    newState,
+			Action:  req.Action,
+			Chain:   req.Chain,
+			Metrics: req.GetMetrics(),
+			Alias:   req.Alias,
+		}
+		if err := s.state2.CreateDeployment(alloc, secrets); err != nil {
+			return "", nil, err
+		}
+		if paused {
+			if err := s.reconciler.pauseLocked(allocId); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	diffs, err := s.reconciler.applyLocked(allocId, deployableTasks)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// an update that does not change anything keeps the last revision
+	if alloc == nil || hasChanges(diffs) || !bytes.Equal(prevState, newState) {
+		rev := &proto.DeploymentRevision{
+			Deployment: allocId,
+			Spec:       newState,
+			Tasks:      deployableTasks,
+			Plugin:     req.Action,
+			CreatedAt:  time.Now().Unix(),
+		}
+		if item, err := s.catalog.GetPlugin(req.Action); err == nil && item != nil {
+			rev.PluginVersion = item.Version
+		}
+		if _, err := s.state2.CreateRevision(rev); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if alloc != nil && len(secrets) != 0 {
+		// the deployment was created before its secrets were generated,
+		// the deployments that reference it still use the old ones
+		s.updateDependentsLocked(allocId)
+	}
+
+	return allocId, diffs, nil
+}
+
+// updateDependentsLocked builds again the deployments that reference
+// the deployment so that their tasks use its new secrets
+func (s *Server) updateDependentsLocked(id string) {
+	dependents, err := s.dependents(id)
+	if err != nil {
+		s.logger.Error("failed to list the dependents of the deployment", "id", id, "err", err)
+		return
+	}
+	for _, dependent := range dependents {
+		s.logger.Info("updating the secrets of the dependent deployment", "id", dependent, "reference", id)
+
+		req := &proto.ApplyRequest{
+			AllocationId: dependent,
+			Input:        []byte("{}"),
+		}
+		if _, _, err := s.createLocked(req, false); err != nil {
+			s.logger.Error("failed to update the dependent deployment, it has to be updated again", "id", dependent, "err", err)
+		}
+	}
+}
+
+// resolveRequest loads the deployment updated by the request (if any) and
+// returns a copy of the request with the fields that are not set filled
+// with the ones stored in the deployment.
+func (s *Server) resolveRequest(req *proto.ApplyRequest) (*proto.Deployment2, *proto.ApplyRequest, error) {
+	req = gproto.Clone(req).(*proto.ApplyRequest)
+
+	if req.AllocationId == "" {
+		if req.Metrics == nil {
+			// metrics are enabled by default
+			req.Metrics = gproto.Bool(true)
+		}
+		return nil, req, nil
+	}
+
+	alloc, err := s.state2.GetDeploymentByIdOrPrefix(req.AllocationId)
+	if err != nil {
+		return nil, nil, err
+	}
+	if alloc.Status == proto.Deployment2_Destroyed {
+		return nil, nil, fmt.Errorf("deployment '%s' is destroyed", alloc.Id)
+	}
+
+	if req.Action == "" {
+		req.Action = alloc.Action
+	} else if alloc.Action != "" && !strings.EqualFold(req.Action, alloc.Action) {
+		return nil, nil, fmt.Errorf("cannot change the plugin of deployment '%s' from '%s' to '%s'", alloc.Id, alloc.Action, req.Action)
+	}
+	if req.Chain == "" {
+		req.Chain = alloc.Chain
+	}
+	if req.Metrics == nil {
+		req.Metrics = gproto.Bool(alloc.Metrics)
+	}
+	if req.Alias == "" {
+		req.Alias = alloc.Alias
+	}
+	req.AllocationId = alloc.Id
+
+	return alloc, req, nil
+}
+
+// Plan returns the changes that an Apply request would perform on
+// the deployment without running them in the backend.
+func (s *Server) Plan(req *proto.ApplyRequest) (*proto.Plan, []*proto.TaskDiff, error) {
+	alloc, req, err := s.resolveRequest(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var prevState []byte
+	var prevTasks map[string]*proto.Task
+
+	if alloc != nil {
+		prevState = alloc.Spec
+
+		if prevTasks, err = s.state2.GetTasks(alloc.Id); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	fields, err := s.catalog.Diff(prevState, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to run plugin '%s': %v", req.Action, err)
+	}
+
+	plan := &proto.Plan{
+		Fields: fields,
+	}
+	for _, field := range fields {
+		if field.ForceNew {
+			plan.Blocked = true
+		}
+	}
+	if plan.Blocked {
+		// the plugin cannot build the tasks for this input
+		return plan, nil, nil
+	}
+
+	_, tasks, _, err := s.catalog.Build(prevState, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to run plugin '%s': %v", req.Action, err)
+	}
+	plan.Tasks = redactTasks(tasks)
+
+	return plan, diffTasks(prevTasks, tasks), nil
+}
+
+// redactedValue replaces the content of the data files with secrets
+const redactedValue = "<redacted>"
+
+// redactTasks returns the tasks with the content of the data
+// files that contain secrets redacted
+func redactTasks(tasks map[string]*proto.Task) map[string]*proto.Task {
+	res := make(map[string]*proto.Task, len(tasks))
+	for name, task := range tasks {
+		if len(task.SecretData) != 0 {
+			task = gproto.Clone(task).(*proto.Task)
+			for _, path := range task.SecretData {
+				if _, ok := task.Data[path]; ok {
+					task.Data[path] = redactedValue
+				}
+			}
+		}
+		res[name] = task
+	}
+	return res
+}
+
+// Rollback applies again the spec and the tasks of a previous revision
+// of the deployment. The rollback is recorded as a new revision.
+func (s *Server) Rollback(id string, revision uint64) (uint64, []*proto.TaskDiff, error) {
+	s.reconciler.lock.Lock()
+	defer s.reconciler.lock.Unlock()
+
+	dep, err := s.state2.GetDeploymentByIdOrPrefix(id)
+	if err != nil {
+		return 0, nil, err
+	}
+	if dep.Status == proto.Deployment2_Destroyed {
+		return 0, nil, fmt.Errorf("deployment '%s' is destroyed", dep.Id)
+	}
+
+	rev, err := s.state2.GetRevision(dep.Id, revision)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	s.logger.Info("rollback deployment", "id", dep.Id, "revision", revision)
+
+	dep.Spec = rev.Spec
+	if err := s.state2.UpdateDeployment(dep, nil); err != nil {
+		return 0, nil, err
+	}
+
+	diffs, err := s.reconciler.applyLocked(dep.Id, rev.Tasks)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	newRev := &proto.DeploymentRevision{
+		Deployment:    dep.Id,
+		Spec:          rev.Spec,
+		Tasks:         rev.Tasks,
+		Plugin:        rev.Plugin,
+		PluginVersion: rev.PluginVersion,
+		CreatedAt:     time.Now().Unix(),
+	}
+	newRevision, err := s.state2.CreateRevision(newRev)
+	if err != nil {
+		return 0, nil, err
+	}
+	return newRevision, diffs, nil
+}
+
+// WatchEvents sends the events that match the request starting after the
+// index of the request. If follow is set, it keeps sending the new events
+// until the context is done.
+func (s *Server) WatchEvents(ctx context.Context, req *proto.WatchEventsRequest, send func(*proto.Event2) error) error {
+	filter := &eventFilter{
+		task:  req.Task,
+		types: req.Types,
+	}
+	if req.Deployment != "" {
+		dep, err := s.state2.GetDeploymentByIdOrPrefix(req.Deployment)
+		if err != nil {
+			return err
+		}
+		filter.deployment = dep.Id
+	}
+
+	// subscribe before reading the stored events so that no event is lost
+	var sub *subscription
+	if req.Follow {
+		sub = s.broker.subscribe(filter)
+		defer sub.close()
+	}
+
+	index := req.Index
+
+	events, err := s.state2.ListEvents(filter.deployment, index)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		index = event.Index
+		if !filter.match(event) {
+			continue
 		}
-		if err := s.state2.CreateDeployment(alloc); err != nil {
-			return "", err
+		if err := send(event); err != nil {
+			return err
 		}
 	}
+	if !req.Follow {
+		return nil
+	}
+
+	for {
+		select {
+		case event, ok := <-sub.eventCh:
+			if !ok {
+				return fmt.Errorf("event stream closed, resume from index %d", index)
+			}
+			if event.Index <= index {
+				// already sent from the stored events
+				continue
+			}
+			index = event.Index
+			if err := send(event); err != nil {
+				return err
+			}
 
-	// do it here because if we create the deployment the alloc id is generated now
-	for _, task := range deployableTasks {
-		if task.Labels == nil {
-			task.Labels = map[string]string{}
+		case <-ctx.Done():
+			return nil
 		}
-		task.Labels["deployment"] = allocId
 	}
+}
+
+// TaskLogs sends the output of the container of a task of the deployment
+func (s *Server) TaskLogs(ctx context.Context, req *proto.TaskLogsRequest, send func(*proto.TaskLogsResponse) error) error {
+	c, err := s.taskContainer(req.Deployment, req.Task)
+	if err != nil {
+		return err
+	}
+
+	opts := &backend.LogOptions{
+		Follow: req.Follow,
+		Tail:   req.Tail,
+	}
+	if req.Since != 0 {
+		opts.Since = time.Unix(req.Since, 0)
+	}
+
+	stdout := &logWriter{stream: proto.TaskLogsResponse_Stdout, send: send}
+	stderr := &logWriter{stream: proto.TaskLogsResponse_Stderr, send: send}
+
+	if err := s.swarm.Logs(ctx, c.ID, opts, stdout, stderr); err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+// logWriter sends the output written to it as a log stream message
+type logWriter struct {
+	stream proto.TaskLogsResponse_Stream
+	send   func(*proto.TaskLogsResponse) error
+}
 
-	if err := s.swarm.Deploy("test", deployableTasks); err != nil {
-		panic(err)
+func (l *logWriter) Write(p []byte) (int, error) {
+	resp := &proto.TaskLogsResponse{
+		Stream: l.stream,
+		// the buffer is reused by the writer
+		Data: append([]byte{}, p...),
+	}
+	if err := l.send(resp); err != nil {
+		return 0, err
 	}
+	return len(p), nil
+}
 
-	return allocId, nil
+// TaskExec runs a command in the container of a task of the deployment
+// and returns the exit code of the command.
+func (s *Server) TaskExec(ctx context.Context, setup *proto.TaskExecRequest_Setup, stdin io.Reader, resize <-chan backend.TerminalSize, send func(*proto.TaskExecResponse) error) (int, error) {
+	c, err := s.taskContainer(setup.Deployment, setup.Task)
+	if err != nil {
+		return 0, err
+	}
+	if c.State != backend.ContainerStateRunning {
+		return 0, fmt.Errorf("task '%s' is not running", setup.Task)
+	}
+	if len(setup.Cmd) == 0 {
+		return 0, fmt.Errorf("no command to run")
+	}
+
+	s.logger.Info("exec in task", "id", c.ID, "task", setup.Task, "cmd", setup.Cmd)
+
+	// the writers are used from the input and the output goroutines
+	lock := &sync.Mutex{}
+
+	opts := &backend.ExecOptions{
+		Cmd:    setup.Cmd,
+		Tty:    setup.Tty,
+		Stdin:  stdin,
+		Stdout: &execWriter{lock: lock, send: send},
+		Stderr: &execWriter{lock: lock, send: send, stderr: true},
+		Resize: resize,
+	}
+	return s.swarm.Exec(ctx, c.ID, opts)
+}
+
+// execWriter sends the output written to it as an exec stream message
+type execWriter struct {
+	lock   *sync.Mutex
+	stderr bool
+	send   func(*proto.TaskExecResponse) error
+}
+
+func (e *execWriter) Write(p []byte) (int, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	// the buffer is reused by the writer
+	data := append([]byte{}, p...)
+
+	resp := &proto.TaskExecResponse{}
+	if e.stderr {
+		resp.Stderr = data
+	} else {
+		resp.Stdout = data
+	}
+	if err := e.send(resp); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// taskContainer returns the container of a task of the deployment
+func (s *Server) taskContainer(deployment, task string) (*backend.Container, error) {
+	dep, err := s.state2.GetDeploymentByIdOrPrefix(deployment)
+	if err != nil {
+		return nil, err
+	}
+	containers, err := s.swarm.Tasks(dep.Id)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := containers[task]
+	if !ok {
+		return nil, fmt.Errorf("task '%s' not found in deployment '%s'", task, dep.Id)
+	}
+	return c, nil
+}
+
+// Destroy stops and removes all the tasks of a deployment and marks
+// the deployment as destroyed.
+func (s *Server) Destroy(id string, purgeVolumes bool) error {
+	s.reconciler.lock.Lock()
+	defer s.reconciler.lock.Unlock()
+
+	dep, err := s.state2.GetDeploymentByIdOrPrefix(id)
+	if err != nil {
+		return err
+	}
+	if dep.Status == proto.Deployment2_Destroyed {
+		return fmt.Errorf("deployment '%s' is already destroyed", dep.Id)
+	}
+	if s.reconciler.isPausedLocked(dep.Id) {
+		return fmt.Errorf("deployment '%s' has a volume operation in progress", dep.Id)
+	}
+
+	dependents, err := s.dependents(dep.Id)
+	if err != nil {
+		return err
+	}
+	if len(dependents) != 0 {
+		return fmt.Errorf("deployment '%s' is referenced by the deployments: %s", dep.Id, strings.Join(dependents, ", "))
+	}
+	return s.destroyLocked(dep, purgeVolumes)
+}
+
+// dependents returns the deployments that reference the deployment
+func (s *Server) dependents(id string) ([]string, error) {
+	deployments, err := s.state2.ListDeployments()
+	if err != nil {
+		return nil, err
+	}
+
+	dependents := []string{}
+	for _, dep := range deployments {
+		if dep.Id == id || dep.Status == proto.Deployment2_Destroyed {
+			continue
+		}
+		refs, err := s.catalog.References(dep.Action, dep.Spec)
+		if err != nil {
+			s.logger.Warn("failed to read the references of the deployment", "id", dep.Id, "err", err)
+			continue
+		}
+		for _, ref := range refs {
+			if ref == id {
+				dependents = append(dependents, dep.Id)
+				break
+			}
+		}
+	}
+	return dependents, nil
+}
+
+func (s *Server) destroyLocked(dep *proto.Deployment2, purgeVolumes bool) error {
+	s.logger.Info("destroying deployment", "id", dep.Id, "purge-volumes", purgeVolumes)
+
+	if err := s.swarm.Destroy(dep.Id, purgeVolumes); err != nil {
+		return fmt.Errorf("failed to destroy deployment '%s': %v", dep.Id, err)
+	}
+
+	s.UpdateEvent(&proto.Event2{
+		Id:         uuid.Generate(),
+		Deployment: dep.Id,
+		Type:       proto.DeploymentDestroyed,
+	})
+
+	if err := s.state2.UpdateDeploymentStatus(dep.Id, proto.Deployment2_Destroyed); err != nil {
+		return err
+	}
+	if err := s.state2.DeleteSecrets(dep.Id); err != nil {
+		return err
+	}
+	// the tasks do not run anymore
+	if err := s.state2.PutTaskStates(dep.Id, nil); err != nil {
+		return err
+	}
+	s.reconciler.forgetLocked(dep.Id)
+
+	return nil
 }
 
 /*
@@ -1,20 +1,62 @@
 package server
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/backend"
+	"github.com/umbracle/vesta/internal/mock"
 	"github.com/umbracle/vesta/internal/server/proto"
+	"github.com/umbracle/vesta/internal/server/state2"
+	"github.com/umbracle/vesta/internal/testutil"
+	gproto "google.golang.org/protobuf/proto"
 )
 
 type dummyCatalog struct {
 	prev       []byte
 	createTask *proto.Task
+	tasks      map[string]*proto.Task
+	forceNew   bool
+	req        *proto.ApplyRequest
+
+	// secrets are the secrets generated by Build
+	secrets map[string]string
+
+	// references are the deployments referenced by each state
+	references map[string][]string
 }
 
-func (d *dummyCatalog) Build(prev []byte, req *proto.ApplyRequest) ([]byte, map[string]*proto.Task, error) {
+func (d *dummyCatalog) Build(prev []byte, req *proto.ApplyRequest) ([]byte, map[string]*proto.Task, map[string]string, error) {
 	// this is enough to generate an allocation
 	d.prev = prev
-	return req.Input, map[string]*proto.Task{"task": d.createTask}, nil
+	d.req = req
+	if d.tasks != nil {
+		return req.Input, d.tasks, d.secrets, nil
+	}
+	return req.Input, map[string]*proto.Task{"task": d.createTask}, d.secrets, nil
+}
+
+func (d *dummyCatalog) Diff(prev []byte, req *proto.ApplyRequest) ([]*proto.Plan_FieldDiff, error) {
+	if bytes.Equal(prev, req.Input) {
+		return []*proto.Plan_FieldDiff{}, nil
+	}
+	diff := &proto.Plan_FieldDiff{
+		Name:     "input",
+		Old:      string(prev),
+		New:      string(req.Input),
+		ForceNew: d.forceNew,
+	}
+	return []*proto.Plan_FieldDiff{diff}, nil
 }
 
 func (d *dummyCatalog) ListPlugins() []string {
@@ -25,57 +67,934 @@ func (d *dummyCatalog) GetPlugin(name string) (*proto.Item, error) {
 	return nil, nil
 }
 
+func (d *dummyCatalog) References(plugin string, spec []byte) ([]string, error) {
+	return d.references[string(spec)], nil
+}
+
+func testServer(t *testing.T, catalog Catalog) (*Server, *backend.MockDriver) {
+	state, err := state2.NewState(filepath.Join(t.TempDir(), "state.db"))
+	require.NoError(t, err)
+
+	driver := backend.NewMockDriver()
+
+	srv := &Server{
+		logger:  hclog.NewNullLogger(),
+		state2:  state,
+		catalog: catalog,
+		broker:  newEventBroker(),
+
+		snapshotsDir: t.TempDir(),
+	}
+	srv.seedCtx, srv.seedCancel = context.WithCancel(context.Background())
+	swarmConfig := &backend.SwarmConfig{
+		DataDir:      t.TempDir(),
+		VolumesDir:   t.TempDir(),
+		ArtifactsDir: t.TempDir(),
+	}
+	srv.swarm = backend.NewSwarm(srv.logger, driver, srv, swarmConfig)
+	require.NoError(t, srv.swarm.CreateNetwork())
+
+	config := defaultReconcilerConfig()
+	config.Interval = 50 * time.Millisecond
+	config.RestartDelay = 10 * time.Millisecond
+
+	srv.reconciler = newReconciler(srv.logger, config, state, srv.swarm, srv)
+	srv.reconciler.start()
+
+	srv.compactor = newCompactor(srv.logger, defaultCompactorConfig(), state)
+	srv.compactor.start()
+
+	srv.disk = newDiskMonitor(srv.logger, defaultDiskMonitorConfig(), swarmConfig.VolumesDir, srv.reconciler)
+	srv.disk.statfs = func(path string) (*hostDiskUsage, error) {
+		// the tests check the monitor with the usage they need,
+		// the one of the server does not act on the deployments
+		return nil, fmt.Errorf("host disk usage is not measured in the tests")
+	}
+	srv.disk.start()
+
+	t.Cleanup(func() {
+		srv.Stop()
+		state.Close()
+	})
+	return srv, driver
+}
+
+func waitForEvents(t *testing.T, srv *Server, deployment string, types ...string) {
+	testutil.WaitForResult(func() (bool, error) {
+		events, err := srv.state2.GetEventsByDeployment(deployment)
+		if err != nil {
+			return false, err
+		}
+		found := map[string]bool{}
+		for _, event := range events {
+			found[event.Type] = true
+		}
+		for _, typ := range types {
+			if !found[typ] {
+				return false, fmt.Errorf("event '%s' not found", typ)
+			}
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+}
+
 func TestCreate(t *testing.T) {
-	/*
-		// test that we can create and update an allocation
-		tmpDir := t.TempDir()
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, driver := testServer(t, catalog)
 
-		db, err := bolt.Open(filepath.Join(tmpDir, "path.db"), 0755, nil)
-		require.NoError(t, err)
+	input := []byte{0x1, 0x2, 0x3}
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: input})
+	require.NoError(t, err)
+
+	// 'prev' is empty since there was no previous state
+	require.Empty(t, catalog.prev)
+
+	// the plugin knows the id of the new deployment
+	require.Equal(t, id, catalog.req.AllocationId)
+
+	dep, err := srv.state2.GetDeploymentById(id)
+	require.NoError(t, err)
+	require.Equal(t, input, dep.Spec)
+
+	// the task and the network container are running
+	containers, err := driver.List(context.Background(), map[string]string{"deployment": id})
+	require.NoError(t, err)
+	require.Len(t, containers, 2)
+
+	for _, c := range containers {
+		require.Equal(t, backend.ContainerStateRunning, c.State)
+	}
+
+	spec := driver.Spec("task-" + id)
+	require.Equal(t, "vesta:latest", spec.Image)
+	require.Equal(t, []string{"a"}, spec.Cmd)
+	require.Equal(t, "init-"+id, spec.NamespaceFrom)
+
+	// the events of the task container are tracked
+	waitForEvents(t, srv, id, "create", "start")
+}
+
+func TestCreate_NetworkAliases(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, driver := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}, Alias: "node"})
+	require.NoError(t, err)
+	require.Equal(t, []string{id, "node"}, driver.Aliases("init-"+id))
+
+	// rename the deployment
+	_, _, err = srv.Create(&proto.ApplyRequest{AllocationId: id, Input: []byte{0x1}, Alias: "node2"})
+	require.NoError(t, err)
+	require.Equal(t, []string{id, "node2"}, driver.Aliases("init-"+id))
+}
+
+func TestDestroy(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, driver := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	require.NoError(t, srv.Destroy(id, false))
+
+	// all the containers are removed
+	containers, err := driver.List(context.Background(), map[string]string{"deployment": id})
+	require.NoError(t, err)
+	require.Empty(t, containers)
+
+	dep, err := srv.state2.GetDeploymentById(id)
+	require.NoError(t, err)
+	require.Equal(t, proto.Deployment2_Destroyed, dep.Status)
+
+	waitForEvents(t, srv, id, "stop", "destroy", proto.DeploymentDestroyed)
+
+	// a destroyed deployment cannot be destroyed or updated again
+	require.Error(t, srv.Destroy(id, false))
+
+	_, _, err = srv.Create(&proto.ApplyRequest{Input: []byte{0x2}, AllocationId: id})
+	require.Error(t, err)
+}
+
+func TestDestroy_Referenced(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, _ := testServer(t, catalog)
+
+	el, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	cl, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x2}})
+	require.NoError(t, err)
+
+	catalog.references = map[string][]string{
+		string([]byte{0x2}): {el},
+	}
+
+	// the deployment cannot be destroyed while it is referenced
+	require.Error(t, srv.Destroy(el, false))
+
+	require.NoError(t, srv.Destroy(cl, false))
+	require.NoError(t, srv.Destroy(el, false))
+}
 
-		cfg := &Config{PersistentDB: db}
-		catalog := &dummyCatalog{}
+func TestReconcile_RestartTask(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, driver := testServer(t, catalog)
 
-		srv, _ := NewServer(hclog.NewNullLogger(), cfg)
-		srv.catalog = catalog
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
 
-		catalog.createTask = &proto.Task{
-			Args: []string{"a"},
+	require.NoError(t, driver.Exit("task-"+id, 1))
+
+	// the task is started again
+	testutil.WaitForResult(func() (bool, error) {
+		if restarts := srv.reconciler.taskRestarts(id, "task"); restarts != 1 {
+			return false, fmt.Errorf("expected 1 restart but found %d", restarts)
+		}
+		containers, err := srv.swarm.Tasks(id)
+		if err != nil {
+			return false, err
 		}
+		if state := containers["task"].State; state != backend.ContainerStateRunning {
+			return false, fmt.Errorf("task is not running: %s", state)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
 
-		input := []byte{0x1, 0x2, 0x3}
+	waitForEvents(t, srv, id, "die", proto.TaskRestarting)
+}
 
-		allocid, err := srv.Create(&proto.ApplyRequest{Input: input})
-		require.NoError(t, err)
+func TestReconcile_RestartsStored(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, driver := testServer(t, catalog)
 
-		// 'prev' is empty since there was no previous state
-		require.Empty(t, catalog.prev)
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
 
-		// the allocation should be on the state with sequence=0
-		alloc, err := srv.state.GetAllocation(allocid)
-		require.NoError(t, err)
-		require.Equal(t, alloc.Id, allocid)
-		require.Equal(t, alloc.Sequence, int64(0))
-		require.Equal(t, alloc.Tasks["task"].Args, []string{"a"})
-		require.Equal(t, alloc.InputState, input)
-
-		// update the allocation
-		catalog.createTask = &proto.Task{
-			Args: []string{"b"},
+	require.NoError(t, driver.Exit("task-"+id, 1))
+
+	testutil.WaitForResult(func() (bool, error) {
+		states, err := srv.state2.GetTaskStates(id)
+		if err != nil {
+			return false, err
 		}
+		if len(states) != 1 || states[0].State != proto.TaskState2_Running || states[0].Restarts != 1 {
+			return false, fmt.Errorf("the restart of the task is not stored")
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
 
-		input2 := []byte{0x4, 0x5, 0x6}
+	// a new reconciler (i.e. after the server restarts) loads
+	// the restarts from the stored task states
+	r := newReconciler(srv.logger, srv.reconciler.config, srv.state2, srv.swarm, srv)
+	r.reconcileAll()
+	require.Equal(t, uint64(1), r.taskRestarts(id, "task"))
 
-		allocid2, err := srv.Create(&proto.ApplyRequest{Input: input2, AllocationId: allocid})
-		require.NoError(t, err)
-		require.Equal(t, allocid, allocid2)
-		require.Equal(t, catalog.prev, input)
+	states, err := srv.state2.GetTaskStates(id)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	require.Equal(t, uint64(1), states[0].Restarts)
+}
+
+func TestReconcile_TaskState(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, driver := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	waitForTaskState := func(state proto.TaskState2_State, restarts uint64) {
+		testutil.WaitForResult(func() (bool, error) {
+			states, err := srv.state2.GetTaskStates(id)
+			if err != nil {
+				return false, err
+			}
+			if len(states) != 1 {
+				return false, fmt.Errorf("expected 1 task state but found %d", len(states))
+			}
+			if states[0].State != state || states[0].Restarts != restarts {
+				return false, fmt.Errorf("unexpected task state %s (%d restarts)", states[0].State, states[0].Restarts)
+			}
+			return true, nil
+		}, func(err error) {
+			t.Fatal(err)
+		})
+	}
+
+	waitForTaskState(proto.TaskState2_Running, 0)
+
+	// the task is running again after the restart
+	require.NoError(t, driver.Exit("task-"+id, 1))
+	waitForTaskState(proto.TaskState2_Running, 1)
+
+	// the states are removed with the deployment
+	require.NoError(t, srv.Destroy(id, false))
+
+	states, err := srv.state2.GetTaskStates(id)
+	require.NoError(t, err)
+	require.Empty(t, states)
+}
+
+func TestReconcile_RecreateTask(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, driver := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	// remove the container out of band
+	containers, err := srv.swarm.Tasks(id)
+	require.NoError(t, err)
+	require.NoError(t, srv.swarm.RemoveTask(containers["task"].ID))
+
+	// the container is created again
+	testutil.WaitForResult(func() (bool, error) {
+		containers, err := srv.swarm.Tasks(id)
+		if err != nil {
+			return false, err
+		}
+		c, ok := containers["task"]
+		if !ok {
+			return false, fmt.Errorf("task not found")
+		}
+		if c.State != backend.ContainerStateRunning {
+			return false, fmt.Errorf("task is not running: %s", c.State)
+		}
+		return true, nil
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	require.NotNil(t, driver.Spec("task-"+id))
+}
+
+func TestReconcile_BatchTaskCompleted(t *testing.T) {
+	task := mock.Task()
+	task.Batch = true
+
+	catalog := &dummyCatalog{
+		createTask: task,
+	}
+	srv, driver := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	require.NoError(t, driver.Exit("task-"+id, 0))
+	waitForEvents(t, srv, id, "die")
+
+	// force a reconcile pass, the completed task is not restarted
+	srv.reconciler.reconcileAll()
+	require.Equal(t, uint64(0), srv.reconciler.taskRestarts(id, "task"))
+
+	containers, err := srv.swarm.Tasks(id)
+	require.NoError(t, err)
+	require.Equal(t, backend.ContainerStateExited, containers["task"].State)
+}
+
+func TestReconcile_ArtifactVerification(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "genesis.ssz")
+	require.NoError(t, os.WriteFile(src, []byte("genesis"), 0644))
+
+	task := mock.Task()
+	task.Artifacts = []*proto.Task_Artifact{
+		{
+			Source:      src,
+			Destination: "/genesis.ssz",
+			Sha256:      "0000000000000000000000000000000000000000000000000000000000000000",
+		},
+	}
+	catalog := &dummyCatalog{
+		createTask: task,
+	}
+	srv, driver := testServer(t, catalog)
+
+	// the deployment is created but the task fails
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	waitForEvents(t, srv, id, proto.TaskVerificationFailed)
+	require.Nil(t, driver.Spec("task-"+id))
+
+	states, err := srv.state2.GetTaskStates(id)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	require.Equal(t, proto.TaskState2_Dead, states[0].State)
+	require.Contains(t, states[0].Error, "sha256 mismatch")
+
+	// the artifact is not verified again on the next pass
+	srv.reconciler.reconcileAll()
+
+	events, err := srv.state2.GetEventsByDeployment(id)
+	require.NoError(t, err)
+
+	failures := 0
+	for _, event := range events {
+		if event.Type == proto.TaskVerificationFailed {
+			require.Equal(t, proto.Event2_Error, event.Severity)
+			failures++
+		}
+	}
+	require.Equal(t, 1, failures)
+}
+
+func TestReconcile_ArtifactFetchUnlocked(t *testing.T) {
+	blockCh := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-blockCh
+		w.Write([]byte("genesis"))
+	}))
+	defer server.Close()
+
+	task := mock.Task()
+	task.Artifacts = []*proto.Task_Artifact{
+		{
+			Source:      server.URL + "/genesis.ssz",
+			Destination: "/genesis.ssz",
+		},
+	}
+	catalog := &dummyCatalog{
+		createTask: task,
+	}
+	srv, driver := testServer(t, catalog)
+
+	// the deployment is created while the artifact is downloaded
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	states, err := srv.state2.GetTaskStates(id)
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	require.Equal(t, proto.TaskState2_Pending, states[0].State)
+
+	// the reconciler is not blocked by the download
+	srv.reconciler.reconcileAll()
+	require.Nil(t, driver.Spec("task-"+id))
+
+	close(blockCh)
 
-		// the allocation shoulld be on the state with sequence=1
-		alloc, err = srv.state.GetAllocation(allocid)
+	testutil.WaitForResult(func() (bool, error) {
+		return driver.Spec("task-"+id) != nil, nil
+	}, func(err error) {
+		t.Fatal("task not created")
+	})
+}
+
+func TestReconcile_CreateFailure(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "genesis.ssz")
+
+	failing := mock.Task()
+	failing.Artifacts = []*proto.Task_Artifact{
+		{
+			Source:      src,
+			Destination: "/genesis.ssz",
+		},
+	}
+	catalog := &dummyCatalog{
+		tasks: map[string]*proto.Task{
+			"a": failing,
+			"b": mock.Task(),
+		},
+	}
+	srv, driver := testServer(t, catalog)
+
+	// the artifact does not exist yet, the other task is created anyway
+	// while the artifact is fetched in the background
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	require.Nil(t, driver.Spec("a-"+id))
+	require.NotNil(t, driver.Spec("b-"+id))
+
+	testutil.WaitForResult(func() (bool, error) {
+		states, err := srv.state2.GetTaskStates(id)
+		if err != nil {
+			return false, err
+		}
+		for _, state := range states {
+			if state.Name == "a" && state.State == proto.TaskState2_Dead {
+				if !strings.Contains(state.Error, "failed to fetch the artifacts of task 'a'") {
+					return false, fmt.Errorf("unexpected error '%s'", state.Error)
+				}
+				return true, nil
+			}
+		}
+		return false, fmt.Errorf("task not failed")
+	}, func(err error) {
+		t.Fatal(err)
+	})
+
+	// the task is created once the artifact is available
+	require.NoError(t, os.WriteFile(src, []byte("genesis"), 0644))
+
+	testutil.WaitForResult(func() (bool, error) {
+		return driver.Spec("a-"+id) != nil, nil
+	}, func(err error) {
+		t.Fatal("task not created")
+	})
+}
+
+func TestUpdate_Diff(t *testing.T) {
+	catalog := &dummyCatalog{
+		tasks: map[string]*proto.Task{
+			"a": {Image: "a", Tag: "latest"},
+			"b": {Image: "b", Tag: "latest"},
+			"c": {Image: "c", Tag: "latest"},
+		},
+	}
+	srv, _ := testServer(t, catalog)
+
+	id, diffs, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+	for _, diff := range diffs {
+		require.Equal(t, proto.TaskDiff_Create, diff.Type)
+	}
+
+	before, err := srv.swarm.Tasks(id)
+	require.NoError(t, err)
+	require.Len(t, before, 3)
+
+	// change 'a', keep 'b', remove 'c' and add 'd'
+	catalog.tasks = map[string]*proto.Task{
+		"a": {Image: "a", Tag: "latest", Args: []string{"--a"}},
+		"b": {Image: "b", Tag: "latest"},
+		"d": {Image: "d", Tag: "latest"},
+	}
+	_, diffs, err = srv.Create(&proto.ApplyRequest{Input: []byte{0x2}, AllocationId: id})
+	require.NoError(t, err)
+
+	types := map[string]proto.TaskDiff_Type{}
+	for _, diff := range diffs {
+		types[diff.Name] = diff.Type
+	}
+	require.Equal(t, map[string]proto.TaskDiff_Type{
+		"a": proto.TaskDiff_Replace,
+		"b": proto.TaskDiff_Unchanged,
+		"c": proto.TaskDiff_Destroy,
+		"d": proto.TaskDiff_Create,
+	}, types)
+
+	after, err := srv.swarm.Tasks(id)
+	require.NoError(t, err)
+	require.Len(t, after, 3)
+
+	// only the changed task gets a new container
+	require.NotEqual(t, before["a"].ID, after["a"].ID)
+	require.Equal(t, before["b"].ID, after["b"].ID)
+	require.NotContains(t, after, "c")
+
+	for _, c := range after {
+		require.Equal(t, backend.ContainerStateRunning, c.State)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	catalog := &dummyCatalog{
+		tasks: map[string]*proto.Task{
+			"a": {Image: "a", Tag: "latest"},
+		},
+	}
+	srv, _ := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte("a")})
+	require.NoError(t, err)
+
+	before, err := srv.swarm.Tasks(id)
+	require.NoError(t, err)
+
+	catalog.tasks = map[string]*proto.Task{
+		"a": {Image: "a", Tag: "latest", Args: []string{"--a"}},
+	}
+	plan, diffs, err := srv.Plan(&proto.ApplyRequest{Input: []byte("b"), AllocationId: id})
+	require.NoError(t, err)
+
+	require.False(t, plan.Blocked)
+	require.Len(t, plan.Fields, 1)
+	require.Equal(t, "a", plan.Fields[0].Old)
+	require.Equal(t, "b", plan.Fields[0].New)
+	require.Equal(t, []string{"--a"}, plan.Tasks["a"].Args)
+
+	require.Len(t, diffs, 1)
+	require.Equal(t, proto.TaskDiff_Replace, diffs[0].Type)
+
+	// nothing is changed in the backend nor in the state
+	after, err := srv.swarm.Tasks(id)
+	require.NoError(t, err)
+	require.Equal(t, before["a"].ID, after["a"].ID)
+
+	tasks, err := srv.state2.GetTasks(id)
+	require.NoError(t, err)
+	require.Empty(t, tasks["a"].Args)
+
+	// a change in a force new field blocks the plan
+	catalog.forceNew = true
+
+	plan, diffs, err = srv.Plan(&proto.ApplyRequest{Input: []byte("b"), AllocationId: id})
+	require.NoError(t, err)
+	require.True(t, plan.Blocked)
+	require.Empty(t, plan.Tasks)
+	require.Empty(t, diffs)
+}
+
+func TestCreate_Secrets(t *testing.T) {
+	catalog := &dummyCatalog{
+		tasks: map[string]*proto.Task{
+			"a": {
+				Image: "a",
+				Tag:   "latest",
+				Data: map[string]string{
+					"/jwt.hex": "secret",
+					"/config":  "config",
+				},
+				SecretData: []string{"/jwt.hex"},
+			},
+		},
+		secrets: map[string]string{"jwt": "secret"},
+	}
+	srv, _ := testServer(t, catalog)
+
+	// the secrets are stored with the deployment
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte("a")})
+	require.NoError(t, err)
+
+	secret, err := srv.state2.GetSecret(id, "jwt")
+	require.NoError(t, err)
+	require.Equal(t, "secret", secret)
+
+	// the secrets are redacted in the plan and in the history
+	plan, _, err := srv.Plan(&proto.ApplyRequest{Input: []byte("b"), AllocationId: id})
+	require.NoError(t, err)
+	require.Equal(t, redactedValue, plan.Tasks["a"].Data["/jwt.hex"])
+	require.Equal(t, "config", plan.Tasks["a"].Data["/config"])
+
+	resp, err := (&service{srv: srv}).DeploymentHistory(context.Background(), &proto.DeploymentHistoryRequest{Id: id})
+	require.NoError(t, err)
+	require.Len(t, resp.Revisions, 1)
+	require.Equal(t, redactedValue, resp.Revisions[0].Tasks["a"].Data["/jwt.hex"])
+
+	// but not in the tasks that run
+	tasks, err := srv.state2.GetTasks(id)
+	require.NoError(t, err)
+	require.Equal(t, "secret", tasks["a"].Data["/jwt.hex"])
+
+	// the secrets are removed with the deployment
+	require.NoError(t, srv.Destroy(id, false))
+
+	secret, err = srv.state2.GetSecret(id, "jwt")
+	require.NoError(t, err)
+	require.Empty(t, secret)
+}
+
+func TestCreate_SecretsDependents(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+		references: map[string][]string{},
+	}
+	srv, _ := testServer(t, catalog)
+
+	// an execution deployment created before the secrets were stored
+	execId, _, err := srv.Create(&proto.ApplyRequest{Input: []byte("a")})
+	require.NoError(t, err)
+
+	catalog.references["b"] = []string{execId}
+
+	depId, _, err := srv.Create(&proto.ApplyRequest{Input: []byte("b")})
+	require.NoError(t, err)
+
+	// the secret is generated on the next update and the
+	// deployments that reference it are built again
+	catalog.secrets = map[string]string{"jwt": "secret"}
+
+	_, _, err = srv.Create(&proto.ApplyRequest{Input: []byte("a"), AllocationId: execId})
+	require.NoError(t, err)
+
+	secret, err := srv.state2.GetSecret(execId, "jwt")
+	require.NoError(t, err)
+	require.Equal(t, "secret", secret)
+
+	require.Equal(t, depId, catalog.req.AllocationId)
+	require.Equal(t, "{}", string(catalog.req.Input))
+}
+
+func TestRollback(t *testing.T) {
+	catalog := &dummyCatalog{
+		tasks: map[string]*proto.Task{
+			"a": {Image: "a", Tag: "1"},
+			"b": {Image: "b", Tag: "1"},
+		},
+	}
+	srv, _ := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte("a")})
+	require.NoError(t, err)
+
+	before, err := srv.swarm.Tasks(id)
+	require.NoError(t, err)
+
+	catalog.tasks = map[string]*proto.Task{
+		"a": {Image: "a", Tag: "2"},
+		"b": {Image: "b", Tag: "1"},
+	}
+	_, _, err = srv.Create(&proto.ApplyRequest{Input: []byte("b"), AllocationId: id})
+	require.NoError(t, err)
+
+	revisions, err := srv.state2.ListRevisions(id)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	// an update that does not change anything is not recorded
+	_, _, err = srv.Create(&proto.ApplyRequest{Input: []byte("b"), AllocationId: id})
+	require.NoError(t, err)
+
+	revisions, err = srv.state2.ListRevisions(id)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	// roll back to the first revision
+	revision, diffs, err := srv.Rollback(id, 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), revision)
+
+	types := map[string]proto.TaskDiff_Type{}
+	for _, diff := range diffs {
+		types[diff.Name] = diff.Type
+	}
+	require.Equal(t, proto.TaskDiff_Replace, types["a"])
+	require.Equal(t, proto.TaskDiff_Unchanged, types["b"])
+
+	dep, err := srv.state2.GetDeploymentById(id)
+	require.NoError(t, err)
+	require.Equal(t, []byte("a"), dep.Spec)
+
+	tasks, err := srv.state2.GetTasks(id)
+	require.NoError(t, err)
+	require.Equal(t, "1", tasks["a"].Tag)
+
+	// the untouched task keeps running in the same container
+	after, err := srv.swarm.Tasks(id)
+	require.NoError(t, err)
+	require.Equal(t, before["b"].ID, after["b"].ID)
+	require.Equal(t, backend.ContainerStateRunning, after["a"].State)
+
+	// the revision does not exist
+	_, _, err = srv.Rollback(id, 10)
+	require.Error(t, err)
+}
+
+func TestUpdate_StoredRequest(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, _ := testServer(t, catalog)
+
+	req := &proto.ApplyRequest{
+		Action:  "geth",
+		Chain:   "goerli",
+		Metrics: gproto.Bool(false),
+		Alias:   "node",
+		Input:   []byte("a"),
+	}
+	id, _, err := srv.Create(req)
+	require.NoError(t, err)
+
+	dep, err := srv.state2.GetDeploymentById(id)
+	require.NoError(t, err)
+	require.Equal(t, "geth", dep.Action)
+	require.Equal(t, "goerli", dep.Chain)
+	require.False(t, dep.Metrics)
+	require.Equal(t, "node", dep.Alias)
+
+	// update by alias without the identity of the deployment
+	_, _, err = srv.Create(&proto.ApplyRequest{AllocationId: "node", Input: []byte("b")})
+	require.NoError(t, err)
+
+	require.Equal(t, "geth", catalog.req.Action)
+	require.Equal(t, "goerli", catalog.req.Chain)
+	require.False(t, catalog.req.GetMetrics())
+	require.Equal(t, "a", string(catalog.prev))
+
+	// the metrics flag is updated only if it is set
+	_, _, err = srv.Create(&proto.ApplyRequest{AllocationId: id, Input: []byte("b"), Metrics: gproto.Bool(true)})
+	require.NoError(t, err)
+
+	dep, err = srv.state2.GetDeploymentById(id)
+	require.NoError(t, err)
+	require.True(t, dep.Metrics)
+	require.Equal(t, "b", string(dep.Spec))
+
+	// the plugin cannot change
+	_, _, err = srv.Create(&proto.ApplyRequest{AllocationId: id, Action: "prysm", Input: []byte("c")})
+	require.Error(t, err)
+
+	// the plugin name is not case sensitive
+	_, _, err = srv.Create(&proto.ApplyRequest{AllocationId: id, Action: "Geth", Input: []byte("c")})
+	require.NoError(t, err)
+}
+
+func TestCreate_DefaultMetrics(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, _ := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte("a")})
+	require.NoError(t, err)
+
+	dep, err := srv.state2.GetDeploymentById(id)
+	require.NoError(t, err)
+	require.True(t, dep.Metrics)
+	require.True(t, catalog.req.GetMetrics())
+}
+
+func TestWatchEvents(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, driver := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+	waitForEvents(t, srv, id, "create", "start")
+
+	// without follow, only the stored events are returned
+	var events []*proto.Event2
+	err = srv.WatchEvents(context.Background(), &proto.WatchEventsRequest{Deployment: id}, func(event *proto.Event2) error {
+		events = append(events, event)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// follow the 'die' events after the create event
+	req := &proto.WatchEventsRequest{
+		Deployment: id,
+		Types:      []string{"start", "die"},
+		Index:      events[0].Index,
+		Follow:     true,
+	}
+	eventCh := make(chan *proto.Event2, 10)
+	doneCh := make(chan error)
+	go func() {
+		doneCh <- srv.WatchEvents(ctx, req, func(event *proto.Event2) error {
+			eventCh <- event
+			return nil
+		})
+	}()
+
+	// the stored 'start' event
+	event := <-eventCh
+	require.Equal(t, "start", event.Type)
+
+	require.NoError(t, driver.Exit("task-"+id, 1))
+
+	select {
+	case event := <-eventCh:
+		require.Equal(t, "die", event.Type)
+		require.Greater(t, event.Index, events[1].Index)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout")
+	}
+
+	cancel()
+	require.NoError(t, <-doneCh)
+}
+
+func TestTaskLogs(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, driver := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	require.NoError(t, driver.Log("task-"+id, false, "a\n"))
+	require.NoError(t, driver.Log("task-"+id, true, "b\n"))
+	require.NoError(t, driver.Log("task-"+id, false, "c\n"))
+
+	logs := func(req *proto.TaskLogsRequest) []*proto.TaskLogsResponse {
+		var res []*proto.TaskLogsResponse
+		err := srv.TaskLogs(context.Background(), req, func(resp *proto.TaskLogsResponse) error {
+			res = append(res, resp)
+			return nil
+		})
 		require.NoError(t, err)
-		require.Equal(t, alloc.Sequence, int64(1))
-		require.Equal(t, alloc.Tasks["task"].Args, []string{"b"})
-		require.Equal(t, alloc.InputState, input2)
-	*/
+		return res
+	}
+
+	res := logs(&proto.TaskLogsRequest{Deployment: id, Task: "task"})
+	require.Len(t, res, 3)
+	require.Equal(t, proto.TaskLogsResponse_Stdout, res[0].Stream)
+	require.Equal(t, proto.TaskLogsResponse_Stderr, res[1].Stream)
+	require.Equal(t, "b\n", string(res[1].Data))
+
+	res = logs(&proto.TaskLogsRequest{Deployment: id, Task: "task", Tail: "1"})
+	require.Len(t, res, 1)
+	require.Equal(t, "c\n", string(res[0].Data))
+
+	// the task does not exist
+	err = srv.TaskLogs(context.Background(), &proto.TaskLogsRequest{Deployment: id, Task: "task2"}, nil)
+	require.Error(t, err)
+}
+
+func TestTaskExec(t *testing.T) {
+	catalog := &dummyCatalog{
+		createTask: mock.Task(),
+	}
+	srv, _ := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	var stdout []byte
+	send := func(resp *proto.TaskExecResponse) error {
+		require.Empty(t, resp.Stderr)
+		stdout = append(stdout, resp.Stdout...)
+		return nil
+	}
+
+	setup := &proto.TaskExecRequest_Setup{
+		Deployment: id,
+		Task:       "task",
+		Cmd:        []string{"cat", "-"},
+	}
+	exitCode, err := srv.TaskExec(context.Background(), setup, strings.NewReader("input"), nil, send)
+	require.NoError(t, err)
+	require.Equal(t, 0, exitCode)
+	require.Equal(t, "cat -\ninput", string(stdout))
+
+	// the task does not exist
+	setup.Task = "task2"
+	_, err = srv.TaskExec(context.Background(), setup, nil, nil, send)
+	require.Error(t, err)
+
+	// there is no command
+	setup.Task, setup.Cmd = "task", nil
+	_, err = srv.TaskExec(context.Background(), setup, nil, nil, send)
+	require.Error(t, err)
 }
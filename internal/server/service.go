@@ -2,7 +2,10 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"io"
 
+	"github.com/umbracle/vesta/internal/backend"
 	"github.com/umbracle/vesta/internal/server/proto"
 )
 
@@ -13,13 +16,21 @@ type service struct {
 }
 
 func (s *service) Apply(ctx context.Context, req *proto.ApplyRequest) (*proto.ApplyResponse, error) {
+	if req.DryRun {
+		plan, diffs, err := s.srv.Plan(req)
+		if err != nil {
+			return nil, err
+		}
+		return &proto.ApplyResponse{Id: req.AllocationId, Tasks: diffs, Plan: plan}, nil
+	}
+
 	// create
-	id, err := s.srv.Create(req)
+	id, diffs, err := s.srv.Create(req)
 	if err != nil {
 		return nil, err
 	}
 
-	return &proto.ApplyResponse{Id: id}, nil
+	return &proto.ApplyResponse{Id: id, Tasks: diffs}, nil
 }
 
 func (s *service) DeploymentList(ctx context.Context, req *proto.ListDeploymentRequest) (*proto.ListDeploymentResponse, error) {
@@ -35,12 +46,17 @@ func (s *service) DeploymentList(ctx context.Context, req *proto.ListDeploymentR
 }
 
 func (s *service) DeploymentStatus(ctx context.Context, req *proto.DeploymentStatusRequest) (*proto.DeploymentStatusResponse, error) {
-	deployment, err := s.srv.state2.GetDeploymentById(req.Id)
+	deployment, err := s.srv.state2.GetDeploymentByIdOrPrefix(req.Id)
 	if err != nil {
 		return nil, err
 	}
 
-	events, err := s.srv.state2.GetEventsByDeployment(req.Id)
+	events, err := s.srv.state2.GetEventsByDeployment(deployment.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.srv.state2.GetTaskStates(deployment.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -48,23 +64,156 @@ func (s *service) DeploymentStatus(ctx context.Context, req *proto.DeploymentSta
 	resp := &proto.DeploymentStatusResponse{
 		Allocation: deployment,
 		Events:     events,
+		Tasks:      tasks,
+		Health:     deploymentHealth(tasks),
+		DiskUsage:  s.srv.disk.deploymentUsage(deployment.Id),
+	}
+	if item, err := s.srv.catalog.GetPlugin(deployment.Action); err == nil && item != nil {
+		resp.ExpectedDiskSize = item.DiskSize[deployment.Chain]
 	}
 	return resp, nil
 }
 
-func (s *service) Destroy(ctx context.Context, req *proto.DestroyRequest) (*proto.DestroyResponse, error) {
-	/*
-		alloc, err := s.srv.state.AllocationByAliasOrIDOrPrefix(req.Id)
-		if err != nil {
-			return nil, err
-		}
+func (s *service) DeploymentHistory(ctx context.Context, req *proto.DeploymentHistoryRequest) (*proto.DeploymentHistoryResponse, error) {
+	deployment, err := s.srv.state2.GetDeploymentByIdOrPrefix(req.Id)
+	if err != nil {
+		return nil, err
+	}
 
-		if err := s.srv.state.DestroyAllocation(alloc.Id); err != nil {
-			return nil, err
+	revisions, err := s.srv.state2.ListRevisions(deployment.Id)
+	if err != nil {
+		return nil, err
+	}
+	for _, rev := range revisions {
+		rev.Tasks = redactTasks(rev.Tasks)
+	}
+
+	resp := &proto.DeploymentHistoryResponse{
+		Revisions: revisions,
+	}
+	return resp, nil
+}
+
+func (s *service) DeploymentRollback(ctx context.Context, req *proto.DeploymentRollbackRequest) (*proto.DeploymentRollbackResponse, error) {
+	revision, diffs, err := s.srv.Rollback(req.Id, req.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.DeploymentRollbackResponse{
+		Revision: revision,
+		Tasks:    diffs,
+	}
+	return resp, nil
+}
+
+func (s *service) VolumeSnapshot(ctx context.Context, req *proto.VolumeSnapshotRequest) (*proto.VolumeSnapshotResponse, error) {
+	snapshot, err := s.srv.VolumeSnapshot(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.VolumeSnapshotResponse{
+		Snapshot: snapshot,
+	}
+	return resp, nil
+}
+
+func (s *service) VolumeRestore(ctx context.Context, req *proto.VolumeRestoreRequest) (*proto.VolumeRestoreResponse, error) {
+	if err := s.srv.VolumeRestore(req); err != nil {
+		return nil, err
+	}
+	return &proto.VolumeRestoreResponse{}, nil
+}
+
+func (s *service) VolumeList(ctx context.Context, req *proto.VolumeListRequest) (*proto.VolumeListResponse, error) {
+	snapshots, err := s.srv.VolumeList(req.Deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &proto.VolumeListResponse{
+		Snapshots: snapshots,
+	}
+	return resp, nil
+}
+
+func (s *service) WatchEvents(req *proto.WatchEventsRequest, stream proto.VestaService_WatchEventsServer) error {
+	return s.srv.WatchEvents(stream.Context(), req, func(event *proto.Event2) error {
+		return stream.Send(&proto.WatchEventsResponse{Event: event})
+	})
+}
+
+func (s *service) TaskLogs(req *proto.TaskLogsRequest, stream proto.VestaService_TaskLogsServer) error {
+	return s.srv.TaskLogs(stream.Context(), req, stream.Send)
+}
+
+func (s *service) TaskExec(stream proto.VestaService_TaskExecServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	setup := req.Setup
+	if setup == nil {
+		return fmt.Errorf("the first message must include the setup of the command")
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	stdinReader, stdinWriter := io.Pipe()
+	defer stdinReader.Close()
+
+	resizeCh := make(chan backend.TerminalSize, 1)
+	if size := setup.Size; size != nil {
+		resizeCh <- backend.TerminalSize{Height: uint(size.Height), Width: uint(size.Width)}
+	}
+
+	// forward the input and the resizes of the client
+	go func() {
+		defer close(resizeCh)
+
+		for {
+			msg, err := stream.Recv()
+			if err == io.EOF {
+				stdinWriter.Close()
+				return
+			}
+			if err != nil {
+				stdinWriter.CloseWithError(err)
+				cancel()
+				return
+			}
+			if len(msg.Stdin) != 0 {
+				if _, err := stdinWriter.Write(msg.Stdin); err != nil {
+					return
+				}
+			}
+			if msg.StdinClose {
+				stdinWriter.Close()
+			}
+			if size := msg.Resize; size != nil {
+				select {
+				case resizeCh <- backend.TerminalSize{Height: uint(size.Height), Width: uint(size.Width)}:
+				case <-ctx.Done():
+					return
+				}
+			}
 		}
-		return &proto.DestroyResponse{}, nil
-	*/
-	return nil, nil
+	}()
+
+	exitCode, err := s.srv.TaskExec(ctx, setup, stdinReader, resizeCh, stream.Send)
+	if err != nil {
+		return err
+	}
+	return stream.Send(&proto.TaskExecResponse{Exited: true, ExitCode: int32(exitCode)})
+}
+
+func (s *service) Destroy(ctx context.Context, req *proto.DestroyRequest) (*proto.DestroyResponse, error) {
+	if err := s.srv.Destroy(req.Id, req.PurgeVolumes); err != nil {
+		return nil, err
+	}
+	return &proto.DestroyResponse{}, nil
 }
 
 func (s *service) CatalogList(ctx context.Context, req *proto.CatalogListRequest) (*proto.CatalogListResponse, error) {
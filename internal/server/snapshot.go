@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/umbracle/vesta/internal/server/proto"
+	"github.com/umbracle/vesta/internal/uuid"
+)
+
+// VolumeSnapshot archives a volume of a task of the deployment. The tasks of
+// the deployment are stopped during the snapshot so that the data is consistent.
+func (s *Server) VolumeSnapshot(req *proto.VolumeSnapshotRequest) (*proto.Snapshot, error) {
+	dep, err := s.volumeDeployment(req.Deployment, req.Task, req.Volume)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &proto.Snapshot{
+		Id:         uuid.Generate(),
+		Deployment: dep.Id,
+		Task:       req.Task,
+		Volume:     req.Volume,
+		Path:       req.Path,
+		Compressed: req.Compress,
+	}
+	if snapshot.Path == "" {
+		name := snapshot.Id + ".tar"
+		if snapshot.Compressed {
+			name += ".zst"
+		}
+		snapshot.Path = filepath.Join(s.snapshotsDir, name)
+	} else if snapshot.Path, err = s.snapshotPath(snapshot.Path); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("snapshot volume", "deployment", dep.Id, "task", req.Task, "volume", req.Volume, "path", snapshot.Path)
+
+	if err := s.reconciler.pause(dep.Id); err != nil {
+		return nil, err
+	}
+	defer s.reconciler.resume(dep.Id)
+
+	size, err := writeArchive(s.swarm.VolumePath(dep.Id, req.Task, req.Volume), snapshot.Path, snapshot.Compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot volume '%s': %v", req.Volume, err)
+	}
+	snapshot.Size = size
+	snapshot.CreatedAt = time.Now().Unix()
+
+	if err := s.state2.CreateSnapshot(snapshot); err != nil {
+		return nil, err
+	}
+
+	s.UpdateEvent(&proto.Event2{
+		Id:         uuid.Generate(),
+		Deployment: dep.Id,
+		Task:       req.Task,
+		Type:       proto.VolumeSnapshotted,
+		Attributes: map[string]string{
+			"volume":   req.Volume,
+			"snapshot": snapshot.Id,
+		},
+	})
+	return snapshot, nil
+}
+
+// snapshotPath returns the path of a snapshot file in the snapshots
+// directory. The server only writes the snapshots in that directory.
+func (s *Server) snapshotPath(path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(s.snapshotsDir, path)
+	}
+	path = filepath.Clean(path)
+
+	rel, err := filepath.Rel(s.snapshotsDir, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("the path of the snapshot '%s' is not in the snapshots directory '%s'", path, s.snapshotsDir)
+	}
+	return path, nil
+}
+
+// VolumeRestore replaces the content of a volume with a snapshot. The volume
+// defaults to the one of the snapshot but it can be a volume of another
+// deployment (i.e. to bootstrap a new node).
+func (s *Server) VolumeRestore(req *proto.VolumeRestoreRequest) error {
+	snapshot, err := s.state2.GetSnapshotByIdOrPrefix(req.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	deployment, task, volume := req.Deployment, req.Task, req.Volume
+	if deployment == "" {
+		deployment = snapshot.Deployment
+	}
+	if task == "" {
+		task = snapshot.Task
+	}
+	if volume == "" {
+		volume = snapshot.Volume
+	}
+
+	dep, err := s.volumeDeployment(deployment, task, volume)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("restore volume", "deployment", dep.Id, "task", task, "volume", volume, "snapshot", snapshot.Id)
+
+	if err := s.reconciler.pause(dep.Id); err != nil {
+		return err
+	}
+	defer s.reconciler.resume(dep.Id)
+
+	if err := extractArchive(context.Background(), snapshot.Path, s.swarm.VolumePath(dep.Id, task, volume), snapshot.Compressed); err != nil {
+		return fmt.Errorf("failed to restore snapshot '%s': %v", snapshot.Id, err)
+	}
+
+	s.UpdateEvent(&proto.Event2{
+		Id:         uuid.Generate(),
+		Deployment: dep.Id,
+		Task:       task,
+		Type:       proto.VolumeRestored,
+		Attributes: map[string]string{
+			"volume":   volume,
+			"snapshot": snapshot.Id,
+		},
+	})
+	return nil
+}
+
+// VolumeList returns the snapshots of the deployment or the
+// snapshots of every deployment if empty
+func (s *Server) VolumeList(deployment string) ([]*proto.Snapshot, error) {
+	if deployment != "" {
+		dep, err := s.state2.GetDeploymentByIdOrPrefix(deployment)
+		if err != nil {
+			return nil, err
+		}
+		deployment = dep.Id
+	}
+	return s.state2.ListSnapshots(deployment)
+}
+
+// volumeDeployment returns the deployment if it is not destroyed
+// and the task has the volume
+func (s *Server) volumeDeployment(id, task, volume string) (*proto.Deployment2, error) {
+	dep, err := s.state2.GetDeploymentByIdOrPrefix(id)
+	if err != nil {
+		return nil, err
+	}
+	if dep.Status == proto.Deployment2_Destroyed {
+		return nil, fmt.Errorf("deployment '%s' is destroyed", dep.Id)
+	}
+
+	tasks, err := s.state2.GetTasks(dep.Id)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := tasks[task]
+	if !ok {
+		return nil, fmt.Errorf("task '%s' not found in deployment '%s'", task, dep.Id)
+	}
+	if _, ok := t.Volumes[volume]; !ok {
+		return nil, fmt.Errorf("volume '%s' not found in task '%s'", volume, task)
+	}
+	return dep, nil
+}
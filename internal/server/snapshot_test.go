@@ -0,0 +1,137 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/mock"
+	"github.com/umbracle/vesta/internal/server/proto"
+	"github.com/umbracle/vesta/internal/testutil"
+)
+
+func TestVolumeSnapshotRestore(t *testing.T) {
+	task := mock.Task()
+	task.Volumes = map[string]*proto.Task_Volume{
+		"data": {Path: "/data"},
+	}
+	catalog := &dummyCatalog{
+		createTask: task,
+	}
+	srv, driver := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	volume := srv.swarm.VolumePath(id, "task", "data")
+	require.NoError(t, os.WriteFile(filepath.Join(volume, "chaindata"), []byte("block 1"), 0644))
+
+	// unknown task or volume
+	_, err = srv.VolumeSnapshot(&proto.VolumeSnapshotRequest{Deployment: id, Task: "task", Volume: "other"})
+	require.Error(t, err)
+
+	snapshot, err := srv.VolumeSnapshot(&proto.VolumeSnapshotRequest{Deployment: id, Task: "task", Volume: "data", Compress: true})
+	require.NoError(t, err)
+	require.True(t, snapshot.Compressed)
+	require.Equal(t, filepath.Join(srv.snapshotsDir, snapshot.Id+".tar.zst"), snapshot.Path)
+
+	snapshots, err := srv.VolumeList(id)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+
+	waitForTask := func(id string) {
+		testutil.WaitForResult(func() (bool, error) {
+			if driver.Spec("task-"+id) == nil {
+				return false, fmt.Errorf("task not created")
+			}
+			return true, nil
+		}, func(err error) {
+			t.Fatal(err)
+		})
+	}
+
+	// the task is created again after the snapshot
+	waitForTask(id)
+	waitForEvents(t, srv, id, proto.VolumeSnapshotted)
+
+	require.NoError(t, os.WriteFile(filepath.Join(volume, "chaindata"), []byte("block 2"), 0644))
+
+	// restore the snapshot into the same deployment
+	require.NoError(t, srv.VolumeRestore(&proto.VolumeRestoreRequest{Snapshot: snapshot.Id[:8]}))
+
+	data, err := os.ReadFile(filepath.Join(volume, "chaindata"))
+	require.NoError(t, err)
+	require.Equal(t, "block 1", string(data))
+
+	waitForTask(id)
+	waitForEvents(t, srv, id, proto.VolumeRestored)
+
+	// restore the snapshot into a new deployment
+	id2, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x2}})
+	require.NoError(t, err)
+
+	require.NoError(t, srv.VolumeRestore(&proto.VolumeRestoreRequest{Snapshot: snapshot.Id, Deployment: id2}))
+
+	data, err = os.ReadFile(filepath.Join(srv.swarm.VolumePath(id2, "task", "data"), "chaindata"))
+	require.NoError(t, err)
+	require.Equal(t, "block 1", string(data))
+
+	waitForTask(id2)
+
+	snapshots, err = srv.VolumeList("")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+}
+
+func TestVolumeSnapshot_Paused(t *testing.T) {
+	task := mock.Task()
+	task.Volumes = map[string]*proto.Task_Volume{
+		"data": {Path: "/data"},
+	}
+	catalog := &dummyCatalog{
+		createTask: task,
+	}
+	srv, driver := testServer(t, catalog)
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+
+	require.NoError(t, srv.reconciler.pause(id))
+
+	// the tasks are not created while the deployment is paused
+	srv.reconciler.reconcileAll()
+	require.Nil(t, driver.Spec("task-"+id))
+
+	// and the deployment cannot be destroyed or paused again
+	require.Error(t, srv.Destroy(id, false))
+	require.Error(t, srv.reconciler.pause(id))
+
+	srv.reconciler.resume(id)
+	srv.reconciler.reconcileAll()
+	require.NotNil(t, driver.Spec("task-"+id))
+}
+
+func TestVolumeSnapshot_Path(t *testing.T) {
+	task := mock.Task()
+	task.Volumes = map[string]*proto.Task_Volume{
+		"data": {Path: "/data"},
+	}
+	srv, _ := testServer(t, &dummyCatalog{createTask: task})
+
+	id, _, err := srv.Create(&proto.ApplyRequest{Input: []byte{0x1}})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(srv.swarm.VolumePath(id, "task", "data"), 0755))
+
+	// the path is relative to the snapshots directory
+	snapshot, err := srv.VolumeSnapshot(&proto.VolumeSnapshotRequest{Deployment: id, Task: "task", Volume: "data", Path: "geth/latest.tar"})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(srv.snapshotsDir, "geth", "latest.tar"), snapshot.Path)
+
+	// the snapshots cannot be written outside of it
+	for _, path := range []string{"../latest.tar", filepath.Join(t.TempDir(), "latest.tar"), "/etc/latest.tar", "."} {
+		_, err := srv.VolumeSnapshot(&proto.VolumeSnapshotRequest{Deployment: id, Task: "task", Volume: "data", Path: path})
+		require.Error(t, err, path)
+	}
+}
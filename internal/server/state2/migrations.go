@@ -1,36 +1,337 @@
 package state2
 
 import (
+	"database/sql"
 	"embed"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
 //go:embed schema/*.sql
 var migrations embed.FS
 
-func (s *State) applyMigrations() error {
-	txn, err := s.db.Begin()
-	if err != nil {
-		return err
-	}
-	defer txn.Rollback()
+// Migration is a versioned change of the schema of the state. The
+// version is the numeric prefix of the name of the file (i.e. 01-deployments.sql).
+type Migration struct {
+	Version uint64
+	Name    string
 
+	// AppliedAt is the time the migration was applied to the
+	// database. It is zero if the migration is pending.
+	AppliedAt time.Time
+
+	sql string
+}
+
+// Pending returns whether the migration is not applied yet
+func (m *Migration) Pending() bool {
+	return m.AppliedAt.IsZero()
+}
+
+// readMigrations returns the migrations embedded in the binary sorted by version
+func readMigrations() ([]*Migration, error) {
 	files, err := migrations.ReadDir("schema")
 	if err != nil {
-		return err
+		return nil, err
 	}
+
+	res := []*Migration{}
+	versions := map[uint64]string{}
 	for _, file := range files {
+		name := strings.TrimSuffix(file.Name(), ".sql")
+
+		prefix := strings.SplitN(name, "-", 2)[0]
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s does not start with a version", file.Name())
+		}
+		if other, ok := versions[version]; ok {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", other, file.Name())
+		}
+		versions[version] = file.Name()
+
 		data, err := migrations.ReadFile("schema/" + file.Name())
 		if err != nil {
-			return err
+			return nil, err
 		}
-		if _, err := txn.Exec(string(data)); err != nil {
-			return fmt.Errorf("failed to apply migration %s: %v", file.Name(), err)
+		res = append(res, &Migration{
+			Version: version,
+			Name:    name,
+			sql:     string(data),
+		})
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Version < res[j].Version
+	})
+	return res, nil
+}
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    name TEXT NOT NULL,
+    applied_at INTEGER NOT NULL
+)`
+
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// hasMigrationsTable returns whether the database has the schema_migrations table
+func hasMigrationsTable(db querier) (bool, error) {
+	var found int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='schema_migrations'").Scan(&found); err != nil {
+		return false, err
+	}
+	return found != 0, nil
+}
+
+// legacyMigrations returns the migrations applied to a database created before
+// the schema_migrations table. Those versions applied the migrations in order
+// and stored the number of migrations applied in the user_version of the database.
+func legacyMigrations(db querier, known []*Migration) ([]*Migration, error) {
+	var count int
+	if err := db.QueryRow("PRAGMA user_version").Scan(&count); err != nil {
+		return nil, err
+	}
+	if count > len(known) {
+		return nil, fmt.Errorf("the schema version of the database (%d) is newer than the one supported (%d)", count, len(known))
+	}
+	if count == 0 {
+		// the first versions did not count the migrations either
+		baseline, err := checkBaseline(db)
+		if err != nil {
+			return nil, err
+		}
+		if baseline {
+			count = baselineVersion
 		}
 	}
+	return known[:count], nil
+}
 
-	if err := txn.Commit(); err != nil {
-		return err
+// appliedMigrations returns the time each version was applied to the database
+func appliedMigrations(db querier) (map[uint64]time.Time, error) {
+	rows, err := db.Query("SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := map[uint64]time.Time{}
+	for rows.Next() {
+		var version uint64
+		var appliedAt int64
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		res[version] = time.Unix(appliedAt, 0)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// checkVersion fails if the database has migrations that this
+// binary does not know about (i.e. it was used by a newer version)
+func checkVersion(known []*Migration, applied map[uint64]time.Time) error {
+	var latest uint64
+	if len(known) != 0 {
+		latest = known[len(known)-1].Version
+	}
+	for version := range applied {
+		if version > latest {
+			return fmt.Errorf("the schema version of the database (%d) is newer than the one supported (%d)", version, latest)
+		}
 	}
 	return nil
 }
+
+// baselineVersion is the last migration of the schema used before the
+// migrations were versioned. Databases created by those versions do not
+// have the schema_migrations table.
+const baselineVersion = 2
+
+// baselineSchema are the columns of the tables in the baseline schema
+var baselineSchema = map[string][]string{
+	"deployments": {"id", "name", "spec"},
+	"events":      {"id", "deployment_id", "task", "type"},
+}
+
+// tableColumns returns the columns of the table in order or nil if the
+// table does not exist
+func tableColumns(db querier, table string) ([]string, error) {
+	rows, err := db.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		res = append(res, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// checkBaseline is called for a database without the schema_migrations table.
+// It returns true if the database was created with the baseline schema and
+// fails if it has tables that do not match it, since the migrations after the
+// baseline cannot be applied safely on an unknown schema.
+func checkBaseline(db querier) (bool, error) {
+	columns, err := tableColumns(db, "deployments")
+	if err != nil {
+		return false, err
+	}
+	if len(columns) == 0 {
+		// new database
+		return false, nil
+	}
+	for table, expected := range baselineSchema {
+		columns, err := tableColumns(db, table)
+		if err != nil {
+			return false, err
+		}
+		if strings.Join(columns, ",") != strings.Join(expected, ",") {
+			return false, fmt.Errorf("the database does not have a schema version and the table %s does not match the baseline schema (columns: %s)", table, strings.Join(columns, ", "))
+		}
+	}
+	return true, nil
+}
+
+// applyMigrations applies in order the migrations that are not applied
+// yet in a single transaction and returns them.
+func (s *State) applyMigrations() ([]*Migration, error) {
+	known, err := readMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	txn, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer txn.Rollback()
+
+	found, err := hasMigrationsTable(txn)
+	if err != nil {
+		return nil, err
+	}
+	var legacy []*Migration
+	if !found {
+		if legacy, err = legacyMigrations(txn, known); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := txn.Exec(createMigrationsTable); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	// record the migrations applied before the versioned migrations
+	for _, m := range legacy {
+		if _, err := txn.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)", m.Version, m.Name, now.Unix()); err != nil {
+			return nil, err
+		}
+	}
+
+	applied, err := appliedMigrations(txn)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(known, applied); err != nil {
+		return nil, err
+	}
+
+	res := []*Migration{}
+	for _, m := range known {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+		if _, err := txn.Exec(m.sql); err != nil {
+			return nil, fmt.Errorf("failed to apply migration %s: %v", m.Name, err)
+		}
+		if _, err := txn.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)", m.Version, m.Name, now.Unix()); err != nil {
+			return nil, err
+		}
+		m.AppliedAt = now
+		res = append(res, m)
+	}
+
+	if err := txn.Commit(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MigrationStatus returns the migrations known by the binary and whether
+// they are applied to the database in the path, without applying them.
+func MigrationStatus(path string) ([]*Migration, error) {
+	known, err := readMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := openDB(path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	found, err := hasMigrationsTable(db)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		legacy, err := legacyMigrations(db, known)
+		if err != nil {
+			return nil, err
+		}
+		// the time the legacy migrations were applied is not known
+		for _, m := range legacy {
+			m.AppliedAt = time.Unix(0, 0)
+		}
+		return known, nil
+	}
+
+	applied, err := appliedMigrations(db)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkVersion(known, applied); err != nil {
+		return nil, err
+	}
+	for _, m := range known {
+		if appliedAt, ok := applied[m.Version]; ok {
+			m.AppliedAt = appliedAt
+		}
+	}
+	return known, nil
+}
+
+// Migrate applies the pending migrations to the database in the
+// path and returns them.
+func Migrate(path string) ([]*Migration, error) {
+	db, err := openDB(path)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	s := &State{db: db}
+	return s.applyMigrations()
+}
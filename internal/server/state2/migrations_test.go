@@ -0,0 +1,176 @@
+package state2
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+func TestMigrations_Apply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	known, err := readMigrations()
+	require.NoError(t, err)
+
+	// nothing is applied on a new database
+	status, err := MigrationStatus(path)
+	require.NoError(t, err)
+	require.Len(t, status, len(known))
+	for _, m := range status {
+		require.True(t, m.Pending())
+	}
+
+	applied, err := Migrate(path)
+	require.NoError(t, err)
+	require.Len(t, applied, len(known))
+
+	// the migrations are only applied once
+	applied, err = Migrate(path)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+
+	s, err := NewState(path)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	status, err = MigrationStatus(path)
+	require.NoError(t, err)
+	for _, m := range status {
+		require.False(t, m.Pending())
+	}
+}
+
+func TestMigrations_NewerDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	s, err := NewState(path)
+	require.NoError(t, err)
+
+	// a migration from a newer binary
+	_, err = s.db.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (1000, 'future', 0)")
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+
+	_, err = NewState(path)
+	require.Error(t, err)
+
+	_, err = MigrationStatus(path)
+	require.Error(t, err)
+}
+
+// createBaselineDB creates a database with the schema used before the
+// migrations were versioned
+func createBaselineDB(t *testing.T, path string) {
+	db, err := openDB(path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	known, err := readMigrations()
+	require.NoError(t, err)
+	for _, m := range known {
+		if m.Version <= baselineVersion {
+			_, err = db.Exec(m.sql)
+			require.NoError(t, err)
+		}
+	}
+
+	_, err = db.Exec("INSERT INTO deployments (id, name, spec) VALUES ('a', 'name', 'spec')")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO events (id, deployment_id, task, type) VALUES ('1', 'a', 'task', 'create')")
+	require.NoError(t, err)
+}
+
+func TestMigrations_BaselineDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	createBaselineDB(t, path)
+
+	s, err := NewState(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	// the deployment and the events are upgraded with the new columns
+	dep, err := s.GetDeploymentById("a")
+	require.NoError(t, err)
+	require.Equal(t, "name", dep.Name)
+	require.Equal(t, proto.Deployment2_Running, dep.Status)
+	require.True(t, dep.Metrics)
+
+	events, err := s.GetEventsByDeployment("a")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(1), events[0].Index)
+	require.Equal(t, proto.Event2_Info, events[0].Severity)
+
+	require.NoError(t, s.PutTasks("a", map[string]*proto.Task{"task": {}}))
+
+	status, err := MigrationStatus(path)
+	require.NoError(t, err)
+	for _, m := range status {
+		require.False(t, m.Pending())
+	}
+}
+
+func TestMigrations_UnknownDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	db, err := openDB(path)
+	require.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE deployments (id TEXT PRIMARY KEY, other TEXT)")
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, err = MigrationStatus(path)
+	require.Error(t, err)
+
+	_, err = NewState(path)
+	require.Error(t, err)
+}
+
+func TestMigrations_Versions(t *testing.T) {
+	known, err := readMigrations()
+	require.NoError(t, err)
+
+	for i := 1; i < len(known); i++ {
+		require.Less(t, known[i-1].Version, known[i].Version)
+	}
+}
+
+func TestMigrations_LegacyDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	known, err := readMigrations()
+	require.NoError(t, err)
+
+	db, err := openDB(path)
+	require.NoError(t, err)
+
+	// a database from a version that counted the migrations
+	// applied in the user_version instead of schema_migrations
+	for _, m := range known {
+		_, err = db.Exec(m.sql)
+		require.NoError(t, err)
+	}
+	_, err = db.Exec(fmt.Sprintf("PRAGMA user_version = %d", len(known)))
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	status, err := MigrationStatus(path)
+	require.NoError(t, err)
+	for _, m := range status {
+		require.False(t, m.Pending())
+	}
+
+	// the applied migrations are not applied again
+	applied, err := Migrate(path)
+	require.NoError(t, err)
+	require.Empty(t, applied)
+
+	status, err = MigrationStatus(path)
+	require.NoError(t, err)
+	for _, m := range status {
+		require.False(t, m.Pending())
+	}
+}
@@ -0,0 +1 @@
+ALTER TABLE deployments ADD COLUMN status TEXT NOT NULL DEFAULT 'Running';
@@ -0,0 +1,7 @@
+
+CREATE TABLE IF NOT EXISTS tasks (
+    deployment_id TEXT NOT NULL REFERENCES deployments (id),
+    name TEXT NOT NULL,
+    spec BLOB NOT NULL,
+    PRIMARY KEY (deployment_id, name)
+);
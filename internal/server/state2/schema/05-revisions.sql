@@ -0,0 +1,11 @@
+
+CREATE TABLE IF NOT EXISTS deployment_revisions (
+    deployment_id TEXT NOT NULL REFERENCES deployments (id),
+    revision INTEGER NOT NULL,
+    spec TEXT NOT NULL,
+    tasks BLOB NOT NULL,
+    plugin TEXT NOT NULL,
+    plugin_version TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (deployment_id, revision)
+);
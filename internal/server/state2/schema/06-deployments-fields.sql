@@ -0,0 +1,6 @@
+ALTER TABLE deployments ADD COLUMN action TEXT NOT NULL DEFAULT '';
+ALTER TABLE deployments ADD COLUMN chain TEXT NOT NULL DEFAULT '';
+ALTER TABLE deployments ADD COLUMN metrics INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE deployments ADD COLUMN alias TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS deployments_alias ON deployments (alias) WHERE alias != '';
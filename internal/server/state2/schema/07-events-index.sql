@@ -0,0 +1,16 @@
+-- sqlite cannot add a primary key to an existing table, the events
+-- are copied in insertion order into a new table with the index.
+CREATE TABLE events_new (
+    idx INTEGER PRIMARY KEY AUTOINCREMENT,
+    id TEXT,
+    deployment_id TEXT NOT NULL REFERENCES deployments (id),
+    task TEXT NOT NULL,
+    type TEXT NOT NULL
+);
+
+INSERT INTO events_new (id, deployment_id, task, type)
+    SELECT id, deployment_id, task, type FROM events ORDER BY rowid;
+
+DROP TABLE events;
+
+ALTER TABLE events_new RENAME TO events;
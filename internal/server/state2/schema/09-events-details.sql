@@ -0,0 +1,4 @@
+ALTER TABLE events ADD COLUMN timestamp INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE events ADD COLUMN severity TEXT NOT NULL DEFAULT 'Info';
+ALTER TABLE events ADD COLUMN message TEXT NOT NULL DEFAULT '';
+ALTER TABLE events ADD COLUMN attributes TEXT NOT NULL DEFAULT '{}';
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS secrets (
+    deployment_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (deployment_id, name)
+);
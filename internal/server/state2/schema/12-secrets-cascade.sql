@@ -0,0 +1,15 @@
+-- the secrets are removed along with their deployment, the secrets
+-- of deployments that do not exist are not copied.
+CREATE TABLE secrets_new (
+    deployment_id TEXT NOT NULL REFERENCES deployments (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (deployment_id, name)
+);
+
+INSERT INTO secrets_new (deployment_id, name, value)
+    SELECT deployment_id, name, value FROM secrets WHERE deployment_id IN (SELECT id FROM deployments);
+
+DROP TABLE secrets;
+
+ALTER TABLE secrets_new RENAME TO secrets;
@@ -0,0 +1,2 @@
+-- the destroyed deployments release their aliases
+UPDATE deployments SET alias='' WHERE status='Destroyed';
@@ -0,0 +1,4 @@
+ALTER TABLE deployments ADD COLUMN stop_reason TEXT NOT NULL DEFAULT '';
+
+-- the deployments were only stopped by the disk monitor
+UPDATE deployments SET stop_reason='disk' WHERE status='Stopped';
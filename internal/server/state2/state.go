@@ -2,9 +2,13 @@ package state2
 
 import (
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/umbracle/vesta/internal/server/proto"
+	gproto "google.golang.org/protobuf/proto"
 )
 
 type State struct {
@@ -12,20 +16,25 @@ type State struct {
 }
 
 func NewState(path string) (*State, error) {
-	s := &State{}
-
-	db, err := sql.Open("sqlite3", path+"?_foreign_keys=on")
+	db, err := openDB(path)
 	if err != nil {
 		return nil, err
 	}
-	s.db = db
+	s := &State{db: db}
 
-	if err := s.applyMigrations(); err != nil {
+	if _, err := s.applyMigrations(); err != nil {
+		db.Close()
 		return nil, err
 	}
 	return s, nil
 }
 
+func openDB(path string) (*sql.DB, error) {
+	// wait for the locks of concurrent writers instead of failing and take
+	// the write lock at the start of the transactions to avoid upgrades
+	return sql.Open("sqlite3", path+"?_foreign_keys=on&_busy_timeout=5000&_txlock=immediate")
+}
+
 func (s *State) Close() error {
 	return s.db.Close()
 }
@@ -33,7 +42,7 @@ func (s *State) Close() error {
 func (s *State) ListDeployments() ([]*proto.Deployment2, error) {
 
 	// get the deployments
-	rows, err := s.db.Query("SELECT id, name, spec FROM deployments")
+	rows, err := s.db.Query("SELECT " + deploymentColumns + " FROM deployments")
 	if err != nil {
 		return nil, err
 	}
@@ -41,38 +50,95 @@ func (s *State) ListDeployments() ([]*proto.Deployment2, error) {
 
 	var deployments []*proto.Deployment2
 	for rows.Next() {
-		var id, name, spec string
-		if err := rows.Scan(&id, &name, &spec); err != nil {
+		dep, err := scanDeployment(rows)
+		if err != nil {
 			return nil, err
 		}
-		deployments = append(deployments, &proto.Deployment2{
-			Id:   id,
-			Name: name,
-			Spec: []byte(spec),
-		})
+		deployments = append(deployments, dep)
 	}
 
 	return deployments, nil
 }
 
-func (s *State) CreateDeployment(dep *proto.Deployment2) error {
+// CreateDeployment creates the deployment along with its secrets
+func (s *State) CreateDeployment(dep *proto.Deployment2, secrets map[string]string) error {
+	txn, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
 
 	// create the deployment
-	_, err := s.db.Exec("INSERT INTO deployments (id, name, spec) VALUES (?, ?, ?)", dep.Id, dep.Name, dep.Spec)
+	_, err = txn.Exec("INSERT INTO deployments (id, name, spec, action, chain, metrics, alias) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		dep.Id, dep.Name, dep.Spec, dep.Action, dep.Chain, dep.Metrics, dep.Alias)
 	if err != nil {
 		return err
 	}
+	if err := putSecrets(txn, dep.Id, secrets); err != nil {
+		return err
+	}
 
-	return nil
+	return txn.Commit()
 }
 
-func (s *State) UpdateDeployment(dep *proto.Deployment2) error {
+// UpdateDeployment updates the deployment and stores the new secrets
+func (s *State) UpdateDeployment(dep *proto.Deployment2, secrets map[string]string) error {
+	txn, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
 
 	// update the deployment
-	_, err := s.db.Exec("UPDATE deployments SET name=?, spec=? WHERE id=?", dep.Name, dep.Spec, dep.Id)
+	_, err = txn.Exec("UPDATE deployments SET name=?, spec=?, action=?, chain=?, metrics=?, alias=? WHERE id=?",
+		dep.Name, dep.Spec, dep.Action, dep.Chain, dep.Metrics, dep.Alias, dep.Id)
+	if err != nil {
+		return err
+	}
+	if err := putSecrets(txn, dep.Id, secrets); err != nil {
+		return err
+	}
+
+	return txn.Commit()
+}
+
+func (s *State) UpdateDeploymentStatus(id string, status proto.Deployment2_Status) error {
+
+	query := "UPDATE deployments SET status=?, stop_reason='' WHERE id=?"
+	if status == proto.Deployment2_Destroyed {
+		// a destroyed deployment releases its alias so that it can be reused
+		query = "UPDATE deployments SET status=?, stop_reason='', alias='' WHERE id=?"
+	}
+
+	// update the status of the deployment
+	res, err := s.db.Exec(query, status.String(), id)
+	if err != nil {
+		return err
+	}
+	num, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
+	if num == 0 {
+		return fmt.Errorf("deployment '%s' not found", id)
+	}
+
+	return nil
+}
+
+// StopDeployment marks the deployment as stopped and stores the reason
+func (s *State) StopDeployment(id string, reason string) error {
+	res, err := s.db.Exec("UPDATE deployments SET status=?, stop_reason=? WHERE id=?", proto.Deployment2_Stopped.String(), reason, id)
+	if err != nil {
+		return err
+	}
+	num, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if num == 0 {
+		return fmt.Errorf("deployment '%s' not found", id)
+	}
 
 	return nil
 }
@@ -80,51 +146,499 @@ func (s *State) UpdateDeployment(dep *proto.Deployment2) error {
 func (s *State) GetDeploymentById(id string) (*proto.Deployment2, error) {
 
 	// get the deployment
-	row := s.db.QueryRow("SELECT id, name, spec FROM deployments WHERE id=?", id)
+	row := s.db.QueryRow("SELECT "+deploymentColumns+" FROM deployments WHERE id=?", id)
+
+	return scanDeployment(row)
+}
 
-	var name, spec string
-	if err := row.Scan(&id, &name, &spec); err != nil {
+// GetDeploymentByIdOrPrefix returns the deployment whose id or alias matches
+// or whose id starts with the given value. It fails if the prefix is ambiguous.
+func (s *State) GetDeploymentByIdOrPrefix(id string) (*proto.Deployment2, error) {
+	if id == "" {
+		return nil, fmt.Errorf("deployment id is empty")
+	}
+
+	// substr is used for the prefix instead of LIKE which treats
+	// the '%' and '_' characters in the id as wildcards
+	rows, err := s.db.Query("SELECT "+deploymentColumns+" FROM deployments WHERE id=? OR (alias != '' AND alias=?) OR substr(id, 1, ?)=?", id, id, len(id), id)
+	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	return &proto.Deployment2{
-		Id:   id,
-		Name: name,
-		Spec: []byte(spec),
-	}, nil
+	var deployments []*proto.Deployment2
+	for rows.Next() {
+		dep, err := scanDeployment(rows)
+		if err != nil {
+			return nil, err
+		}
+		if dep.Id == id || (dep.Alias != "" && dep.Alias == id) {
+			// exact match takes precedence over any prefix match
+			return dep, nil
+		}
+		deployments = append(deployments, dep)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(deployments) == 0 {
+		return nil, fmt.Errorf("no deployment found with id or prefix '%s'", id)
+	}
+	if len(deployments) != 1 {
+		return nil, fmt.Errorf("more than one deployment found with prefix '%s'", id)
+	}
+	return deployments[0], nil
 }
 
+// PutTasks replaces the tasks of the deployment
+func (s *State) PutTasks(deployment string, tasks map[string]*proto.Task) error {
+	txn, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	if _, err := txn.Exec("DELETE FROM tasks WHERE deployment_id=?", deployment); err != nil {
+		return err
+	}
+	for name, task := range tasks {
+		spec, err := gproto.Marshal(task)
+		if err != nil {
+			return err
+		}
+		if _, err := txn.Exec("INSERT INTO tasks (deployment_id, name, spec) VALUES (?, ?, ?)", deployment, name, spec); err != nil {
+			return err
+		}
+	}
+
+	return txn.Commit()
+}
+
+// GetTasks returns the tasks of the deployment indexed by name
+func (s *State) GetTasks(deployment string) (map[string]*proto.Task, error) {
+	rows, err := s.db.Query("SELECT name, spec FROM tasks WHERE deployment_id=?", deployment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := map[string]*proto.Task{}
+	for rows.Next() {
+		var name string
+		var spec []byte
+		if err := rows.Scan(&name, &spec); err != nil {
+			return nil, err
+		}
+		task := &proto.Task{}
+		if err := gproto.Unmarshal(spec, task); err != nil {
+			return nil, fmt.Errorf("failed to decode task '%s': %v", name, err)
+		}
+		tasks[name] = task
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// PutTaskStates replaces the states of the tasks of the deployment
+func (s *State) PutTaskStates(deployment string, states []*proto.TaskState2) error {
+	txn, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer txn.Rollback()
+
+	if _, err := txn.Exec("DELETE FROM task_states WHERE deployment_id=?", deployment); err != nil {
+		return err
+	}
+	for _, state := range states {
+		_, err := txn.Exec("INSERT INTO task_states (deployment_id, name, state, exit_code, error, restarts, started_at, finished_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			deployment, state.Name, state.State.String(), state.ExitCode, state.Error, state.Restarts, state.StartedAt, state.FinishedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return txn.Commit()
+}
+
+// GetTaskStates returns the states of the tasks of the deployment sorted by name
+func (s *State) GetTaskStates(deployment string) ([]*proto.TaskState2, error) {
+	rows, err := s.db.Query("SELECT name, state, exit_code, error, restarts, started_at, finished_at FROM task_states WHERE deployment_id=? ORDER BY name", deployment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	states := []*proto.TaskState2{}
+	for rows.Next() {
+		var stateStr string
+
+		state := &proto.TaskState2{}
+		if err := rows.Scan(&state.Name, &stateStr, &state.ExitCode, &state.Error, &state.Restarts, &state.StartedAt, &state.FinishedAt); err != nil {
+			return nil, err
+		}
+		stateVal, ok := proto.TaskState2_State_value[stateStr]
+		if !ok {
+			return nil, fmt.Errorf("unknown task state '%s'", stateStr)
+		}
+		state.State = proto.TaskState2_State(stateVal)
+		states = append(states, state)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return states, nil
+}
+
+// CreateRevision stores a new revision of the deployment and returns
+// its number. The revisions of a deployment start at 1.
+func (s *State) CreateRevision(rev *proto.DeploymentRevision) (uint64, error) {
+	// the tasks are stored with the same encoding as the revision
+	tasks, err := gproto.Marshal(&proto.DeploymentRevision{Tasks: rev.Tasks})
+	if err != nil {
+		return 0, err
+	}
+
+	txn, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer txn.Rollback()
+
+	var revision uint64
+	row := txn.QueryRow("SELECT COALESCE(MAX(revision), 0) + 1 FROM deployment_revisions WHERE deployment_id=?", rev.Deployment)
+	if err := row.Scan(&revision); err != nil {
+		return 0, err
+	}
+
+	_, err = txn.Exec("INSERT INTO deployment_revisions (deployment_id, revision, spec, tasks, plugin, plugin_version, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		rev.Deployment, revision, rev.Spec, tasks, rev.Plugin, rev.PluginVersion, rev.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := txn.Commit(); err != nil {
+		return 0, err
+	}
+	return revision, nil
+}
+
+// ListRevisions returns the revisions of the deployment in order
+func (s *State) ListRevisions(deployment string) ([]*proto.DeploymentRevision, error) {
+	rows, err := s.db.Query("SELECT deployment_id, revision, spec, tasks, plugin, plugin_version, created_at FROM deployment_revisions WHERE deployment_id=? ORDER BY revision", deployment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []*proto.DeploymentRevision{}
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// GetRevision returns a specific revision of the deployment
+func (s *State) GetRevision(deployment string, revision uint64) (*proto.DeploymentRevision, error) {
+	row := s.db.QueryRow("SELECT deployment_id, revision, spec, tasks, plugin, plugin_version, created_at FROM deployment_revisions WHERE deployment_id=? AND revision=?", deployment, revision)
+
+	rev, err := scanRevision(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("revision %d of deployment '%s' not found", revision, deployment)
+	}
+	return rev, err
+}
+
+func scanRevision(row scanner) (*proto.DeploymentRevision, error) {
+	var spec string
+	var tasks []byte
+
+	rev := &proto.DeploymentRevision{}
+	if err := row.Scan(&rev.Deployment, &rev.Revision, &spec, &tasks, &rev.Plugin, &rev.PluginVersion, &rev.CreatedAt); err != nil {
+		return nil, err
+	}
+	rev.Spec = []byte(spec)
+
+	var obj proto.DeploymentRevision
+	if err := gproto.Unmarshal(tasks, &obj); err != nil {
+		return nil, fmt.Errorf("failed to decode tasks of revision %d: %v", rev.Revision, err)
+	}
+	rev.Tasks = obj.Tasks
+
+	return rev, nil
+}
+
+// snapshotColumns are the columns read by scanSnapshot
+const snapshotColumns = "id, deployment_id, task, volume, path, size, compressed, created_at"
+
+// CreateSnapshot stores the snapshot of a volume
+func (s *State) CreateSnapshot(snapshot *proto.Snapshot) error {
+	_, err := s.db.Exec("INSERT INTO snapshots ("+snapshotColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		snapshot.Id, snapshot.Deployment, snapshot.Task, snapshot.Volume, snapshot.Path, snapshot.Size, snapshot.Compressed, snapshot.CreatedAt)
+	return err
+}
+
+// ListSnapshots returns the snapshots of the deployment in the order they
+// were created. It returns the snapshots of every deployment if empty.
+func (s *State) ListSnapshots(deployment string) ([]*proto.Snapshot, error) {
+	rows, err := s.db.Query("SELECT "+snapshotColumns+" FROM snapshots WHERE ?='' OR deployment_id=? ORDER BY created_at, id", deployment, deployment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := []*proto.Snapshot{}
+	for rows.Next() {
+		snapshot, err := scanSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// GetSnapshotByIdOrPrefix returns the snapshot whose id matches or starts
+// with the given value. It fails if the prefix is ambiguous.
+func (s *State) GetSnapshotByIdOrPrefix(id string) (*proto.Snapshot, error) {
+	rows, err := s.db.Query("SELECT "+snapshotColumns+" FROM snapshots WHERE id LIKE ? || '%'", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []*proto.Snapshot
+	for rows.Next() {
+		snapshot, err := scanSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot.Id == id {
+			return snapshot, nil
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("no snapshot found with id or prefix '%s'", id)
+	}
+	if len(snapshots) != 1 {
+		return nil, fmt.Errorf("more than one snapshot found with prefix '%s'", id)
+	}
+	return snapshots[0], nil
+}
+
+// GetSecret returns the value of a secret of the deployment
+// or an empty value if the secret does not exist
+func (s *State) GetSecret(deployment, name string) (string, error) {
+	var value string
+	err := s.db.QueryRow("SELECT value FROM secrets WHERE deployment_id=? AND name=?", deployment, name).Scan(&value)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+// DeleteSecrets removes the secrets of the deployment
+func (s *State) DeleteSecrets(deployment string) error {
+	_, err := s.db.Exec("DELETE FROM secrets WHERE deployment_id=?", deployment)
+	return err
+}
+
+// putSecrets creates or replaces the secrets of the deployment
+func putSecrets(txn *sql.Tx, deployment string, secrets map[string]string) error {
+	for name, value := range secrets {
+		if _, err := txn.Exec("INSERT OR REPLACE INTO secrets (deployment_id, name, value) VALUES (?, ?, ?)", deployment, name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func scanSnapshot(row scanner) (*proto.Snapshot, error) {
+	snapshot := &proto.Snapshot{}
+	if err := row.Scan(&snapshot.Id, &snapshot.Deployment, &snapshot.Task, &snapshot.Volume, &snapshot.Path, &snapshot.Size, &snapshot.Compressed, &snapshot.CreatedAt); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// deploymentColumns are the columns read by scanDeployment
+const deploymentColumns = "id, name, spec, status, action, chain, metrics, alias, stop_reason"
+
+func scanDeployment(row scanner) (*proto.Deployment2, error) {
+	var spec, status string
+
+	dep := &proto.Deployment2{}
+	if err := row.Scan(&dep.Id, &dep.Name, &spec, &status, &dep.Action, &dep.Chain, &dep.Metrics, &dep.Alias, &dep.StopReason); err != nil {
+		return nil, err
+	}
+
+	statusVal, ok := proto.Deployment2_Status_value[status]
+	if !ok {
+		return nil, fmt.Errorf("unknown deployment status '%s'", status)
+	}
+	dep.Spec = []byte(spec)
+	dep.Status = proto.Deployment2_Status(statusVal)
+
+	return dep, nil
+}
+
+// CreateEvent stores the event and sets its index in the event log
 func (s *State) CreateEvent(event *proto.Event2) error {
+	attributes, err := json.Marshal(event.Attributes)
+	if err != nil {
+		return err
+	}
 
 	// create the event
-	_, err := s.db.Exec("INSERT INTO events (id, deployment_id, task, type) VALUES (?, ?, ?, ?)", event.Id, event.Deployment, event.Task, event.Type)
+	res, err := s.db.Exec("INSERT INTO events (id, deployment_id, task, type, timestamp, severity, message, attributes) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		event.Id, event.Deployment, event.Task, event.Type, event.Timestamp, event.Severity.String(), event.Message, string(attributes))
+	if err != nil {
+		return err
+	}
+	index, err := res.LastInsertId()
 	if err != nil {
 		return err
 	}
+	event.Index = uint64(index)
 
 	return nil
 }
 
 func (s *State) GetEventsByDeployment(id string) ([]*proto.Event2, error) {
 	// get the events
-	rows, err := s.db.Query("SELECT id, deployment_id, task, type FROM events WHERE deployment_id=?", id)
+	rows, err := s.db.Query("SELECT "+eventColumns+" FROM events WHERE deployment_id=? ORDER BY idx", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanEvents(rows)
+}
+
+// ListEvents returns the events after the given index in order. If the
+// deployment is empty, it returns the events of all the deployments.
+func (s *State) ListEvents(deployment string, index uint64) ([]*proto.Event2, error) {
+	rows, err := s.db.Query("SELECT "+eventColumns+" FROM events WHERE idx > ? AND (? = '' OR deployment_id=?) ORDER BY idx", index, deployment, deployment)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
+	return scanEvents(rows)
+}
+
+// CompactEvents removes the events older than maxAge and keeps at most
+// maxEvents events per deployment. A zero value disables each limit.
+// It returns the number of events removed.
+func (s *State) CompactEvents(maxAge time.Duration, maxEvents int) (int64, error) {
+	txn, err := s.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer txn.Rollback()
+
+	var removed int64
+	if maxAge != 0 {
+		res, err := txn.Exec("DELETE FROM events WHERE timestamp < ?", time.Now().Add(-maxAge).UnixNano())
+		if err != nil {
+			return 0, err
+		}
+		num, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		removed += num
+	}
+	if maxEvents != 0 {
+		res, err := txn.Exec(`DELETE FROM events WHERE idx IN (
+			SELECT idx FROM (
+				SELECT idx, ROW_NUMBER() OVER (PARTITION BY deployment_id ORDER BY idx DESC) AS num FROM events
+			) WHERE num > ?
+		)`, maxEvents)
+		if err != nil {
+			return 0, err
+		}
+		num, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		removed += num
+	}
+
+	if err := txn.Commit(); err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// tables are the tables reported by TableRows
+var tables = []string{"deployments", "events", "tasks", "task_states", "deployment_revisions", "snapshots", "secrets"}
+
+// TableRows returns the number of rows of each table
+func (s *State) TableRows() (map[string]int64, error) {
+	res := map[string]int64{}
+	for _, table := range tables {
+		var rows int64
+		if err := s.db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&rows); err != nil {
+			return nil, err
+		}
+		res[table] = rows
+	}
+	return res, nil
+}
+
+// eventColumns are the columns read by scanEvents
+const eventColumns = "idx, id, deployment_id, task, type, timestamp, severity, message, attributes"
+
+func scanEvents(rows *sql.Rows) ([]*proto.Event2, error) {
 	var events []*proto.Event2
 	for rows.Next() {
-		var id, deployment, task, typ string
-		if err := rows.Scan(&id, &deployment, &task, &typ); err != nil {
+		var severity, attributes string
+
+		event := &proto.Event2{}
+		if err := rows.Scan(&event.Index, &event.Id, &event.Deployment, &event.Task, &event.Type, &event.Timestamp, &severity, &event.Message, &attributes); err != nil {
 			return nil, err
 		}
-		events = append(events, &proto.Event2{
-			Id:         id,
-			Deployment: deployment,
-			Task:       task,
-			Type:       typ,
-		})
+		severityVal, ok := proto.Event2_Severity_value[severity]
+		if !ok {
+			return nil, fmt.Errorf("unknown event severity '%s'", severity)
+		}
+		event.Severity = proto.Event2_Severity(severityVal)
+		if err := json.Unmarshal([]byte(attributes), &event.Attributes); err != nil {
+			return nil, fmt.Errorf("failed to decode attributes of event %d: %v", event.Index, err)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
 	return events, nil
@@ -2,7 +2,9 @@ package state2
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/umbracle/vesta/internal/server/proto"
@@ -17,7 +19,7 @@ func TestState(t *testing.T) {
 		Name: "test",
 		Spec: []byte("spec"),
 	}
-	err := s.CreateDeployment(dep)
+	err := s.CreateDeployment(dep, nil)
 	require.NoError(t, err)
 
 	deployments, err := s.ListDeployments()
@@ -33,7 +35,351 @@ func TestState(t *testing.T) {
 	require.Error(t, s.CreateEvent(event))
 
 	event.Deployment = "1"
+	event.Timestamp = 10
+	event.Severity = proto.Event2_Error
+	event.Message = "Task exited with code 1"
+	event.Attributes = map[string]string{"exitCode": "1"}
 	require.NoError(t, s.CreateEvent(event))
+
+	events, err := s.GetEventsByDeployment("1")
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, int64(10), events[0].Timestamp)
+	require.Equal(t, proto.Event2_Error, events[0].Severity)
+	require.Equal(t, event.Message, events[0].Message)
+	require.Equal(t, "1", events[0].Attributes["exitCode"])
+}
+
+func TestState_DeploymentStatus(t *testing.T) {
+	s := newTestState(t)
+
+	dep := &proto.Deployment2{
+		Id:   "abcd",
+		Spec: []byte("spec"),
+	}
+	require.NoError(t, s.CreateDeployment(dep, nil))
+
+	dep, err := s.GetDeploymentById("abcd")
+	require.NoError(t, err)
+	require.Equal(t, proto.Deployment2_Running, dep.Status)
+
+	require.NoError(t, s.UpdateDeploymentStatus("abcd", proto.Deployment2_Destroyed))
+
+	dep, err = s.GetDeploymentById("abcd")
+	require.NoError(t, err)
+	require.Equal(t, proto.Deployment2_Destroyed, dep.Status)
+
+	// the deployment does not exists
+	require.Error(t, s.UpdateDeploymentStatus("efgh", proto.Deployment2_Destroyed))
+
+	// the reason of a stopped deployment is stored until it changes status
+	require.NoError(t, s.StopDeployment("abcd", "disk"))
+
+	dep, err = s.GetDeploymentById("abcd")
+	require.NoError(t, err)
+	require.Equal(t, proto.Deployment2_Stopped, dep.Status)
+	require.Equal(t, "disk", dep.StopReason)
+
+	require.NoError(t, s.UpdateDeploymentStatus("abcd", proto.Deployment2_Running))
+
+	dep, err = s.GetDeploymentById("abcd")
+	require.NoError(t, err)
+	require.Empty(t, dep.StopReason)
+
+	require.Error(t, s.StopDeployment("efgh", "disk"))
+}
+
+func TestState_DeploymentByIdOrPrefix(t *testing.T) {
+	s := newTestState(t)
+
+	for _, id := range []string{"ab", "abc", "abd"} {
+		require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: id, Spec: []byte("spec")}, nil))
+	}
+
+	// exact match
+	dep, err := s.GetDeploymentByIdOrPrefix("ab")
+	require.NoError(t, err)
+	require.Equal(t, "ab", dep.Id)
+
+	// unique prefix
+	dep, err = s.GetDeploymentByIdOrPrefix("abd")
+	require.NoError(t, err)
+	require.Equal(t, "abd", dep.Id)
+
+	// not found
+	_, err = s.GetDeploymentByIdOrPrefix("b")
+	require.Error(t, err)
+
+	// ambiguous prefix
+	_, err = s.GetDeploymentByIdOrPrefix("a")
+	require.Error(t, err)
+
+	// empty id does not match the deployments without alias
+	_, err = s.GetDeploymentByIdOrPrefix("")
+	require.Error(t, err)
+
+	// wildcards are not expanded in the prefix
+	_, err = s.GetDeploymentByIdOrPrefix("a_")
+	require.Error(t, err)
+
+	_, err = s.GetDeploymentByIdOrPrefix("%")
+	require.Error(t, err)
+}
+
+func TestState_DeploymentFields(t *testing.T) {
+	s := newTestState(t)
+
+	dep := &proto.Deployment2{
+		Id:      "abcd",
+		Spec:    []byte("spec"),
+		Action:  "geth",
+		Chain:   "goerli",
+		Metrics: true,
+		Alias:   "node",
+	}
+	require.NoError(t, s.CreateDeployment(dep, nil))
+
+	found, err := s.GetDeploymentById("abcd")
+	require.NoError(t, err)
+	require.Equal(t, "geth", found.Action)
+	require.Equal(t, "goerli", found.Chain)
+	require.True(t, found.Metrics)
+
+	// the deployment can be referenced by its alias
+	found, err = s.GetDeploymentByIdOrPrefix("node")
+	require.NoError(t, err)
+	require.Equal(t, "abcd", found.Id)
+
+	// the alias is unique
+	require.Error(t, s.CreateDeployment(&proto.Deployment2{Id: "efgh", Spec: []byte("spec"), Alias: "node"}, nil))
+
+	// but many deployments can be created without alias
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "efgh", Spec: []byte("spec")}, nil))
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "ijkl", Spec: []byte("spec")}, nil))
+
+	found.Metrics = false
+	found.Chain = "mainnet"
+	require.NoError(t, s.UpdateDeployment(found, nil))
+
+	found, err = s.GetDeploymentById("abcd")
+	require.NoError(t, err)
+	require.False(t, found.Metrics)
+	require.Equal(t, "mainnet", found.Chain)
+
+	// a destroyed deployment releases its alias
+	require.NoError(t, s.UpdateDeploymentStatus("abcd", proto.Deployment2_Destroyed))
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "mnop", Spec: []byte("spec"), Alias: "node"}, nil))
+
+	found, err = s.GetDeploymentByIdOrPrefix("node")
+	require.NoError(t, err)
+	require.Equal(t, "mnop", found.Id)
+}
+
+func TestState_ListEvents(t *testing.T) {
+	s := newTestState(t)
+
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "a", Spec: []byte("spec")}, nil))
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "b", Spec: []byte("spec")}, nil))
+
+	for i, dep := range []string{"a", "b", "a"} {
+		event := &proto.Event2{
+			Id:         fmt.Sprintf("%d", i),
+			Deployment: dep,
+			Task:       "task",
+			Type:       "create",
+		}
+		require.NoError(t, s.CreateEvent(event))
+		require.Equal(t, uint64(i+1), event.Index)
+	}
+
+	events, err := s.ListEvents("", 0)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	events, err = s.ListEvents("a", 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, uint64(1), events[0].Index)
+	require.Equal(t, uint64(3), events[1].Index)
+
+	// only the events after the index
+	events, err = s.ListEvents("a", 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, uint64(3), events[0].Index)
+}
+
+func TestState_CompactEvents(t *testing.T) {
+	s := newTestState(t)
+
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "a", Spec: []byte("spec")}, nil))
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "b", Spec: []byte("spec")}, nil))
+
+	now := time.Now()
+	create := func(dep string, timestamp time.Time) {
+		event := &proto.Event2{Deployment: dep, Type: "start", Timestamp: timestamp.UnixNano()}
+		require.NoError(t, s.CreateEvent(event))
+	}
+
+	// one old event and three recent events for 'a'
+	create("a", now.Add(-2*time.Hour))
+	for i := 0; i < 3; i++ {
+		create("a", now)
+	}
+	create("b", now)
+
+	removed, err := s.CompactEvents(time.Hour, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), removed)
+
+	// only the last two events of each deployment are kept
+	removed, err = s.CompactEvents(0, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), removed)
+
+	events, err := s.ListEvents("a", 0)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, uint64(3), events[0].Index)
+
+	rows, err := s.TableRows()
+	require.NoError(t, err)
+	require.Equal(t, int64(3), rows["events"])
+	require.Equal(t, int64(2), rows["deployments"])
+}
+
+func TestState_Tasks(t *testing.T) {
+	s := newTestState(t)
+
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "a", Spec: []byte("spec")}, nil))
+
+	tasks := map[string]*proto.Task{
+		"a": {Image: "a", Tag: "latest", Args: []string{"--a"}},
+		"b": {Image: "b", Tag: "latest"},
+	}
+	require.NoError(t, s.PutTasks("a", tasks))
+
+	found, err := s.GetTasks("a")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	require.Equal(t, []string{"--a"}, found["a"].Args)
+
+	// the tasks are replaced
+	require.NoError(t, s.PutTasks("a", map[string]*proto.Task{"b": tasks["b"]}))
+
+	found, err = s.GetTasks("a")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "b", found["b"].Image)
+}
+
+func TestState_TaskStates(t *testing.T) {
+	s := newTestState(t)
+
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "a", Spec: []byte("spec")}, nil))
+
+	states := []*proto.TaskState2{
+		{Name: "b", State: proto.TaskState2_Dead, ExitCode: 1, Error: "failed", Restarts: 2},
+		{Name: "a", State: proto.TaskState2_Running, StartedAt: 10},
+	}
+	require.NoError(t, s.PutTaskStates("a", states))
+
+	found, err := s.GetTaskStates("a")
+	require.NoError(t, err)
+	require.Len(t, found, 2)
+	require.Equal(t, "a", found[0].Name)
+	require.Equal(t, int64(10), found[0].StartedAt)
+	require.Equal(t, proto.TaskState2_Dead, found[1].State)
+	require.Equal(t, "failed", found[1].Error)
+	require.Equal(t, uint64(2), found[1].Restarts)
+
+	// the states are replaced
+	require.NoError(t, s.PutTaskStates("a", states[:1]))
+
+	found, err = s.GetTaskStates("a")
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+}
+
+func TestState_Revisions(t *testing.T) {
+	s := newTestState(t)
+
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "a", Spec: []byte("spec")}, nil))
+
+	for i := 1; i <= 2; i++ {
+		rev := &proto.DeploymentRevision{
+			Deployment: "a",
+			Spec:       []byte(fmt.Sprintf("spec-%d", i)),
+			Tasks: map[string]*proto.Task{
+				"a": {Image: "a", Tag: fmt.Sprintf("%d", i)},
+			},
+			Plugin:        "geth",
+			PluginVersion: "0.0.1",
+			CreatedAt:     int64(i),
+		}
+		num, err := s.CreateRevision(rev)
+		require.NoError(t, err)
+		require.Equal(t, uint64(i), num)
+	}
+
+	revisions, err := s.ListRevisions("a")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.Equal(t, uint64(1), revisions[0].Revision)
+	require.Equal(t, uint64(2), revisions[1].Revision)
+
+	rev, err := s.GetRevision("a", 1)
+	require.NoError(t, err)
+	require.Equal(t, []byte("spec-1"), rev.Spec)
+	require.Equal(t, "1", rev.Tasks["a"].Tag)
+	require.Equal(t, "geth", rev.Plugin)
+	require.Equal(t, "0.0.1", rev.PluginVersion)
+
+	_, err = s.GetRevision("a", 3)
+	require.Error(t, err)
+}
+
+func TestState_Snapshots(t *testing.T) {
+	s := newTestState(t)
+
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "a", Spec: []byte("spec")}, nil))
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "b", Spec: []byte("spec")}, nil))
+
+	for i, dep := range []string{"a", "b", "a"} {
+		snapshot := &proto.Snapshot{
+			Id:         fmt.Sprintf("ab%d", i),
+			Deployment: dep,
+			Task:       "node",
+			Volume:     "data",
+			Path:       "/tmp/snapshot",
+			Size:       10,
+			Compressed: i == 0,
+			CreatedAt:  int64(i),
+		}
+		require.NoError(t, s.CreateSnapshot(snapshot))
+	}
+
+	snapshots, err := s.ListSnapshots("")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 3)
+
+	snapshots, err = s.ListSnapshots("a")
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	require.Equal(t, "ab0", snapshots[0].Id)
+	require.True(t, snapshots[0].Compressed)
+	require.Equal(t, uint64(10), snapshots[0].Size)
+
+	snapshot, err := s.GetSnapshotByIdOrPrefix("ab1")
+	require.NoError(t, err)
+	require.Equal(t, "b", snapshot.Deployment)
+
+	// ambiguous prefix
+	_, err = s.GetSnapshotByIdOrPrefix("ab")
+	require.Error(t, err)
+
+	_, err = s.GetSnapshotByIdOrPrefix("c")
+	require.Error(t, err)
 }
 
 func newTestState(t *testing.T) *State {
@@ -43,3 +389,78 @@ func newTestState(t *testing.T) *State {
 	}
 	return s
 }
+
+func TestState_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	s, err := NewState(path)
+	require.NoError(t, err)
+
+	dep := &proto.Deployment2{
+		Id:   "abcd",
+		Spec: []byte("spec"),
+	}
+	require.NoError(t, s.CreateDeployment(dep, nil))
+	require.NoError(t, s.UpdateDeploymentStatus("abcd", proto.Deployment2_Destroyed))
+	require.NoError(t, s.Close())
+
+	// the migrations already applied are not applied again
+	s, err = NewState(path)
+	require.NoError(t, err)
+	defer s.Close()
+
+	dep, err = s.GetDeploymentById("abcd")
+	require.NoError(t, err)
+	require.Equal(t, proto.Deployment2_Destroyed, dep.Status)
+}
+
+func TestState_Secrets(t *testing.T) {
+	s := newTestState(t)
+
+	// the secret does not exist
+	value, err := s.GetSecret("a", "jwt")
+	require.NoError(t, err)
+	require.Empty(t, value)
+
+	// the secrets of unknown deployments are not stored
+	require.Error(t, s.UpdateDeployment(&proto.Deployment2{Id: "a", Spec: []byte("spec")}, map[string]string{"jwt": "secret1"}))
+
+	dep := &proto.Deployment2{Id: "a", Spec: []byte("spec")}
+	require.NoError(t, s.CreateDeployment(dep, map[string]string{"jwt": "secret1"}))
+	require.NoError(t, s.CreateDeployment(&proto.Deployment2{Id: "b", Spec: []byte("spec")}, map[string]string{"jwt": "secret2"}))
+
+	value, err = s.GetSecret("a", "jwt")
+	require.NoError(t, err)
+	require.Equal(t, "secret1", value)
+
+	// the secrets are kept unless they are replaced
+	require.NoError(t, s.UpdateDeployment(dep, nil))
+
+	value, err = s.GetSecret("a", "jwt")
+	require.NoError(t, err)
+	require.Equal(t, "secret1", value)
+
+	require.NoError(t, s.UpdateDeployment(dep, map[string]string{"jwt": "secret3"}))
+
+	value, err = s.GetSecret("a", "jwt")
+	require.NoError(t, err)
+	require.Equal(t, "secret3", value)
+
+	rows, err := s.TableRows()
+	require.NoError(t, err)
+	require.Equal(t, int64(2), rows["secrets"])
+
+	require.NoError(t, s.DeleteSecrets("a"))
+
+	value, err = s.GetSecret("a", "jwt")
+	require.NoError(t, err)
+	require.Empty(t, value)
+
+	// the secrets are removed along with the deployment
+	_, err = s.db.Exec("DELETE FROM deployments WHERE id='b'")
+	require.NoError(t, err)
+
+	rows, err = s.TableRows()
+	require.NoError(t, err)
+	require.Zero(t, rows["secrets"])
+}
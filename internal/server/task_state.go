@@ -0,0 +1,81 @@
+package server
+
+import (
+	"github.com/umbracle/vesta/internal/backend"
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+// taskState computes the state of a task from the state of its
+// container. A task without a container is pending.
+func taskState(name string, task *proto.Task, c *backend.Container, restarts uint64) *proto.TaskState2 {
+	state := &proto.TaskState2{
+		Name:     name,
+		State:    proto.TaskState2_Pending,
+		Restarts: restarts,
+	}
+	if c == nil {
+		return state
+	}
+
+	state.ExitCode = int32(c.ExitCode)
+	state.Error = c.Error
+	if !c.StartedAt.IsZero() {
+		state.StartedAt = c.StartedAt.Unix()
+	}
+	if !c.FinishedAt.IsZero() {
+		state.FinishedAt = c.FinishedAt.Unix()
+	}
+
+	switch c.State {
+	case backend.ContainerStateRunning:
+		state.State = proto.TaskState2_Running
+
+	case backend.ContainerStateRestarting:
+		state.State = proto.TaskState2_Restarting
+
+	case backend.ContainerStateExited, backend.ContainerStateDead:
+		if task.Batch && c.State == backend.ContainerStateExited && c.ExitCode == 0 {
+			state.State = proto.TaskState2_Completed
+		} else {
+			state.State = proto.TaskState2_Dead
+		}
+	}
+	return state
+}
+
+// failedTaskState is the state of a task that could not be created
+func failedTaskState(name string, reason string) *proto.TaskState2 {
+	return &proto.TaskState2{
+		Name:  name,
+		State: proto.TaskState2_Dead,
+		Error: reason,
+	}
+}
+
+// deploymentHealth summarizes the states of the tasks of a deployment
+func deploymentHealth(states []*proto.TaskState2) proto.DeploymentStatusResponse_Health {
+	if len(states) == 0 {
+		return proto.DeploymentStatusResponse_Unknown
+	}
+
+	var failing, pending int
+	for _, state := range states {
+		switch state.State {
+		case proto.TaskState2_Dead, proto.TaskState2_Restarting:
+			failing++
+		case proto.TaskState2_Pending:
+			pending++
+		}
+	}
+
+	switch {
+	case failing == len(states):
+		return proto.DeploymentStatusResponse_Unhealthy
+	case failing != 0:
+		return proto.DeploymentStatusResponse_Degraded
+	case pending != 0:
+		return proto.DeploymentStatusResponse_Pending
+	default:
+		return proto.DeploymentStatusResponse_Healthy
+	}
+}
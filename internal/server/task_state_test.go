@@ -0,0 +1,72 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/umbracle/vesta/internal/backend"
+	"github.com/umbracle/vesta/internal/server/proto"
+)
+
+func TestTaskState(t *testing.T) {
+	now := time.Now()
+
+	cases := []struct {
+		task     *proto.Task
+		c        *backend.Container
+		expected proto.TaskState2_State
+	}{
+		{&proto.Task{}, nil, proto.TaskState2_Pending},
+		{&proto.Task{}, &backend.Container{State: backend.ContainerStateCreated}, proto.TaskState2_Pending},
+		{&proto.Task{}, &backend.Container{State: backend.ContainerStateRunning}, proto.TaskState2_Running},
+		{&proto.Task{}, &backend.Container{State: backend.ContainerStateRestarting}, proto.TaskState2_Restarting},
+		{&proto.Task{}, &backend.Container{State: backend.ContainerStateExited}, proto.TaskState2_Dead},
+		{&proto.Task{}, &backend.Container{State: backend.ContainerStateDead}, proto.TaskState2_Dead},
+		// a batch task is completed if it exits successfully
+		{&proto.Task{Batch: true}, &backend.Container{State: backend.ContainerStateExited}, proto.TaskState2_Completed},
+		{&proto.Task{Batch: true}, &backend.Container{State: backend.ContainerStateExited, ExitCode: 1}, proto.TaskState2_Dead},
+	}
+	for _, c := range cases {
+		state := taskState("a", c.task, c.c, 1)
+		require.Equal(t, c.expected, state.State)
+		require.Equal(t, uint64(1), state.Restarts)
+	}
+
+	state := taskState("a", &proto.Task{}, &backend.Container{
+		State:      backend.ContainerStateExited,
+		ExitCode:   2,
+		Error:      "failed",
+		StartedAt:  now,
+		FinishedAt: now,
+	}, 0)
+	require.Equal(t, int32(2), state.ExitCode)
+	require.Equal(t, "failed", state.Error)
+	require.Equal(t, now.Unix(), state.StartedAt)
+	require.Equal(t, now.Unix(), state.FinishedAt)
+}
+
+func TestDeploymentHealth(t *testing.T) {
+	states := func(s ...proto.TaskState2_State) []*proto.TaskState2 {
+		res := []*proto.TaskState2{}
+		for _, state := range s {
+			res = append(res, &proto.TaskState2{State: state})
+		}
+		return res
+	}
+
+	cases := []struct {
+		states   []*proto.TaskState2
+		expected proto.DeploymentStatusResponse_Health
+	}{
+		{states(), proto.DeploymentStatusResponse_Unknown},
+		{states(proto.TaskState2_Running, proto.TaskState2_Completed), proto.DeploymentStatusResponse_Healthy},
+		{states(proto.TaskState2_Running, proto.TaskState2_Pending), proto.DeploymentStatusResponse_Pending},
+		{states(proto.TaskState2_Running, proto.TaskState2_Dead), proto.DeploymentStatusResponse_Degraded},
+		{states(proto.TaskState2_Pending, proto.TaskState2_Restarting), proto.DeploymentStatusResponse_Degraded},
+		{states(proto.TaskState2_Dead, proto.TaskState2_Restarting), proto.DeploymentStatusResponse_Unhealthy},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.expected, deploymentHealth(c.states))
+	}
+}
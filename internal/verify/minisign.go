@@ -0,0 +1,100 @@
+package verify
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Minisign verifies the minisign signature of the message:
+//
+//	untrusted comment: <comment>
+//	base64(<algorithm> <key id> <signature>)
+//	trusted comment: <comment>
+//	base64(<global signature>)
+//
+// The public key is either the content of the public key
+// file or only the base64 encoded key.
+func Minisign(publicKey string, signature, message []byte) error {
+	key, err := parseMinisignKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(signature)), "\n")
+	if len(lines) != 4 {
+		return fmt.Errorf("invalid minisign signature format")
+	}
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+		return fmt.Errorf("invalid minisign signature")
+	}
+	algorithm, keyID, sig := sig[:2], sig[2:10], sig[10:]
+
+	if !bytes.Equal(keyID, key.id) {
+		return fmt.Errorf("signature key id %X does not match the public key %X", keyID, key.id)
+	}
+
+	switch string(algorithm) {
+	case "Ed":
+	case "ED":
+		// prehashed signature for large files
+		hash := blake2b.Sum512(message)
+		message = hash[:]
+	default:
+		return fmt.Errorf("unknown minisign signature algorithm '%s'", algorithm)
+	}
+	if !ed25519.Verify(key.key, message, sig) {
+		return fmt.Errorf("invalid signature")
+	}
+
+	// the global signature signs the trusted comment
+	comment, ok := trimPrefix(lines[2], "trusted comment: ")
+	if !ok {
+		return fmt.Errorf("minisign signature without trusted comment")
+	}
+	globalSig, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid minisign global signature")
+	}
+	signed := append(append([]byte{}, sig...), comment...)
+	if !ed25519.Verify(key.key, signed, globalSig) {
+		return fmt.Errorf("invalid signature of the trusted comment")
+	}
+	return nil
+}
+
+type minisignKey struct {
+	id  []byte
+	key ed25519.PublicKey
+}
+
+func parseMinisignKey(publicKey string) (*minisignKey, error) {
+	// the key is the last line of the public key file
+	lines := strings.Split(strings.TrimSpace(publicKey), "\n")
+	raw := strings.TrimSpace(lines[len(lines)-1])
+
+	buf, err := base64.StdEncoding.DecodeString(raw)
+	if err != nil || len(buf) != 2+8+ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid minisign public key")
+	}
+	if string(buf[:2]) != "Ed" {
+		return nil, fmt.Errorf("unknown minisign key algorithm '%s'", buf[:2])
+	}
+	return &minisignKey{id: buf[2:10], key: ed25519.PublicKey(buf[10:])}, nil
+}
+
+func trimPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(s, prefix), true
+}
@@ -0,0 +1,82 @@
+package verify
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Error is returned when the content of an artifact does not
+// match its checksum or its signature
+type Error struct {
+	Source string
+	Reason string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("verification of artifact '%s' failed: %s", e.Source, e.Reason)
+}
+
+// ParseSHA256 validates a sha256 digest in hex. The digest
+// can include the 'sha256:' prefix.
+func ParseSHA256(digest string) (string, error) {
+	digest = strings.ToLower(strings.TrimPrefix(digest, "sha256:"))
+	if buf, err := hex.DecodeString(digest); err != nil || len(buf) != sha256.Size {
+		return "", fmt.Errorf("'%s' is not a valid sha256 digest", digest)
+	}
+	return digest, nil
+}
+
+// FileDigest returns the sha256 digest in hex of the file
+func FileDigest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SHA256 checks that the sha256 digest of the file of the
+// artifact downloaded from source is the expected one
+func SHA256(source, path, expected string) error {
+	expected, err := ParseSHA256(expected)
+	if err != nil {
+		return err
+	}
+	digest, err := FileDigest(path)
+	if err != nil {
+		return err
+	}
+	if digest != expected {
+		return &Error{
+			Source: source,
+			Reason: fmt.Sprintf("sha256 mismatch, expected %s but found %s", expected, digest),
+		}
+	}
+	return nil
+}
+
+// Signature checks the minisign signature of the file of the
+// artifact downloaded from source
+func Signature(source, path string, publicKey string, signature []byte) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := Minisign(publicKey, signature, data); err != nil {
+		return &Error{
+			Source: source,
+			Reason: err.Error(),
+		}
+	}
+	return nil
+}
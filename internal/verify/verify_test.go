@@ -0,0 +1,95 @@
+package verify
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestSHA256(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "genesis.ssz")
+	require.NoError(t, os.WriteFile(path, []byte("genesis"), 0644))
+
+	hash := sha256.Sum256([]byte("genesis"))
+	digest := hex.EncodeToString(hash[:])
+
+	require.NoError(t, SHA256("src", path, digest))
+	require.NoError(t, SHA256("src", path, "sha256:"+digest))
+
+	other := sha256.Sum256([]byte("other"))
+	err := SHA256("src", path, hex.EncodeToString(other[:]))
+	require.Error(t, err)
+	require.IsType(t, &Error{}, err)
+
+	for _, digest := range []string{"abcd", "md5:abcd", "sha256:xyz"} {
+		_, err := ParseSHA256(digest)
+		require.Error(t, err)
+	}
+}
+
+func TestMinisign(t *testing.T) {
+	signer := newTestSigner(t)
+	message := []byte("genesis")
+
+	for _, prehashed := range []bool{false, true} {
+		sig := signer.sign(message, prehashed)
+		require.NoError(t, Minisign(signer.publicKey, sig, message))
+
+		// the message was modified
+		require.Error(t, Minisign(signer.publicKey, sig, []byte("other")))
+	}
+
+	// signed with another key
+	other := newTestSigner(t)
+	require.Error(t, Minisign(signer.publicKey, other.sign(message, false), message))
+
+	// invalid formats
+	require.Error(t, Minisign("key", signer.sign(message, false), message))
+	require.Error(t, Minisign(signer.publicKey, []byte("signature"), message))
+}
+
+type testSigner struct {
+	id        []byte
+	key       ed25519.PrivateKey
+	publicKey string
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	id := pub[:8]
+	raw := append(append([]byte("Ed"), id...), pub...)
+
+	return &testSigner{
+		id:        id,
+		key:       priv,
+		publicKey: "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(raw) + "\n",
+	}
+}
+
+func (s *testSigner) sign(message []byte, prehashed bool) []byte {
+	algorithm := "Ed"
+	if prehashed {
+		algorithm = "ED"
+		hash := blake2b.Sum512(message)
+		message = hash[:]
+	}
+	sig := ed25519.Sign(s.key, message)
+
+	comment := "timestamp:1666000000"
+	globalSig := ed25519.Sign(s.key, append(append([]byte{}, sig...), comment...))
+
+	raw := append(append([]byte(algorithm), s.id...), sig...)
+	return []byte("untrusted comment: signature\n" +
+		base64.StdEncoding.EncodeToString(raw) + "\n" +
+		"trusted comment: " + comment + "\n" +
+		base64.StdEncoding.EncodeToString(globalSig) + "\n")
+}
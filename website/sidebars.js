@@ -32,10 +32,19 @@ const sidebars = {
       label: 'Command Line Interface',
       items: [
         'cli/server',
+        'cli/server-migrate',
         'cli/deploy',
         'cli/destroy',
+        'cli/logs',
+        'cli/exec',
         'cli/deployment-list',
         'cli/deployment-status',
+        'cli/deployment-events',
+        'cli/deployment-history',
+        'cli/deployment-rollback',
+        'cli/volume-snapshot',
+        'cli/volume-restore',
+        'cli/volume-ls',
         'cli/catalog-list',
         'cli/catalog-inspect',
       ],